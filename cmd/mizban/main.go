@@ -1,16 +1,57 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
+	"github.com/mizbancloud/cli/pkg/api"
 	"github.com/mizbancloud/cli/pkg/cli"
+	"github.com/mizbancloud/cli/pkg/prompt"
 )
 
+// Exit codes, so scripts can tell why a command failed
+const (
+	exitError       = 1
+	exitAborted     = 3
+	exitInterrupted = 130
+)
+
+// interruptGrace is how long a command gets to stop after Ctrl-C before
+// the process exits anyway
+const interruptGrace = 2 * time.Second
+
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+		time.Sleep(interruptGrace)
+		fmt.Fprintln(os.Stderr, "Interrupted")
+		os.Exit(exitInterrupted)
+	}()
+
 	rootCmd := cli.NewRootCmd()
-	if err := rootCmd.Execute(); err != nil {
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		if errors.Is(err, api.ErrDryRun) {
+			return
+		}
+		if errors.Is(err, prompt.ErrAborted) {
+			fmt.Fprintln(os.Stderr, "Aborted")
+			os.Exit(exitAborted)
+		}
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "Interrupted")
+			os.Exit(exitInterrupted)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintln(os.Stderr, "Timed out: the command took longer than --timeout")
+			os.Exit(exitError)
+		}
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 }
@@ -2,110 +2,253 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/types"
 )
 
+// ErrDryRun is returned instead of sending a write request when --dry-run is set
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// defaultCtx is the context used by clients created with NewClient
+var defaultCtx = context.Background()
+
+// SetContext sets the context for clients created afterwards by NewClient.
+// The root command uses it so --timeout and Ctrl-C cancel in-flight requests.
+func SetContext(ctx context.Context) {
+	defaultCtx = ctx
+}
+
 type Client struct {
 	httpClient *http.Client
 	config     *config.Config
+	ctx        context.Context
 }
 
 type Response struct {
 	Success bool            `json:"success"`
 	Message string          `json:"message"`
 	Data    json.RawMessage `json:"data"`
+	Meta    *Meta           `json:"meta,omitempty"`
 }
 
+// ErrorResponse is the body of a failed request. Field errors may be a single
+// message or a list; only the first message per field is kept.
 type ErrorResponse struct {
-	Success bool              `json:"success"`
-	Message string            `json:"message"`
-	Errors  map[string]string `json:"errors,omitempty"`
+	Success bool                            `json:"success"`
+	Message string                          `json:"message"`
+	Errors  map[string]types.FlexibleString `json:"errors,omitempty"`
+}
+
+// insecureWarning is printed once per run when TLS verification is off
+var insecureWarning sync.Once
+
+// UserAgent identifies the CLI build to the API, e.g.
+// "mizban-cli/0.1.0 (linux/amd64)"
+func UserAgent() string {
+	return fmt.Sprintf("mizban-cli/%s (%s/%s)", config.Version, runtime.GOOS, runtime.GOARCH)
 }
 
 func NewClient() *Client {
+	cfg := config.GetConfig()
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if cfg.InsecureTLS() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		httpClient.Transport = transport
+		insecureWarning.Do(func() {
+			fmt.Fprintln(os.Stderr, "WARNING: TLS certificate verification is disabled (--insecure); only use this with gateways you trust")
+		})
+	}
+
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		config: config.GetConfig(),
+		httpClient: httpClient,
+		config:     cfg,
+		ctx:        defaultCtx,
 	}
 }
 
-func (c *Client) request(method, endpoint string, body interface{}) (*Response, error) {
+// Context returns the context the client's requests run under
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
+// WithContext returns a copy of the client whose requests run under ctx
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+func (c *Client) request(ctx context.Context, method, endpoint string, body interface{}) (*Response, error) {
 	url := c.config.BaseURL + endpoint
 
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	if c.config.DryRun && method != http.MethodGet {
+		printDryRun(method, url, body)
+		return nil, ErrDryRun
+	}
+
+	// Rate-limited requests are retried after the pause the API asks for
+	var resp *http.Response
+	var respBody []byte
+	for attempt := 0; ; attempt++ {
+		var err error
+		resp, respBody, err = c.send(ctx, method, url, jsonBody, body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= c.config.MaxRetries {
+			break
+		}
+
+		delay := retryDelay(resp.Header, attempt, time.Now())
+		fmt.Fprintf(os.Stderr, "Rate limited by the API; retrying in %s (retry %d of %d)\n", delay.Round(time.Second), attempt+1, c.config.MaxRetries)
+		if err := sleepCtx(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	var response Response
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		if resp.StatusCode >= 400 {
+			return nil, &APIError{StatusCode: resp.StatusCode}
+		}
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	if !response.Success || resp.StatusCode >= 400 {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			errResp = ErrorResponse{Message: response.Message}
+		}
+		return nil, newAPIError(resp.StatusCode, errResp)
+	}
+
+	return &response, nil
+}
+
+// send makes a single HTTP request and reads the whole response body
+func (c *Client) send(ctx context.Context, method, url string, jsonBody []byte, body interface{}) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent())
 
 	if c.config.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	if c.config.Debug {
+		debugRequest(req, body)
+	}
+
+	// A context deadline replaces the client's default timeout
+	httpClient := c.httpClient
+	if _, ok := ctx.Deadline(); ok {
+		noTimeout := *c.httpClient
+		noTimeout.Timeout = 0
+		httpClient = &noTimeout
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) && !c.config.InsecureTLS() {
+			return nil, nil, fmt.Errorf("error making request: %w\n(for a self-hosted gateway with a self-signed certificate, pass --insecure)", err)
+		}
+		return nil, nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response: %w", err)
+		return nil, nil, fmt.Errorf("error reading response: %w", err)
 	}
 
-	if resp.StatusCode == 401 {
-		return nil, fmt.Errorf("unauthorized: please login again using 'mizban login'")
+	if c.config.Debug {
+		debugResponse(resp, respBody)
 	}
 
-	if resp.StatusCode == 429 {
-		return nil, fmt.Errorf("rate limited: please wait and try again")
-	}
+	return resp, respBody, nil
+}
 
-	var response Response
-	if err := json.Unmarshal(respBody, &response); err != nil {
-		return nil, fmt.Errorf("error parsing response: %w", err)
+// printDryRun shows the request that would have been sent
+func printDryRun(method, url string, body interface{}) {
+	fmt.Printf("[dry-run] %s %s\n", method, url)
+	if body == nil {
+		return
 	}
-
-	if !response.Success {
-		return nil, fmt.Errorf("API error: %s", response.Message)
+	jsonBody, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return
 	}
-
-	return &response, nil
+	fmt.Println(string(jsonBody))
 }
 
 func (c *Client) Get(endpoint string) (*Response, error) {
-	return c.request(http.MethodGet, endpoint, nil)
+	return c.GetCtx(c.ctx, endpoint)
 }
 
 func (c *Client) Post(endpoint string, body interface{}) (*Response, error) {
-	return c.request(http.MethodPost, endpoint, body)
+	return c.PostCtx(c.ctx, endpoint, body)
 }
 
 func (c *Client) Put(endpoint string, body interface{}) (*Response, error) {
-	return c.request(http.MethodPut, endpoint, body)
+	return c.PutCtx(c.ctx, endpoint, body)
 }
 
 func (c *Client) Delete(endpoint string) (*Response, error) {
-	return c.request(http.MethodDelete, endpoint, nil)
+	return c.DeleteCtx(c.ctx, endpoint)
+}
+
+func (c *Client) GetCtx(ctx context.Context, endpoint string) (*Response, error) {
+	return c.request(ctx, http.MethodGet, endpoint, nil)
+}
+
+func (c *Client) PostCtx(ctx context.Context, endpoint string, body interface{}) (*Response, error) {
+	return c.request(ctx, http.MethodPost, endpoint, body)
+}
+
+func (c *Client) PutCtx(ctx context.Context, endpoint string, body interface{}) (*Response, error) {
+	return c.request(ctx, http.MethodPut, endpoint, body)
+}
+
+func (c *Client) DeleteCtx(ctx context.Context, endpoint string) (*Response, error) {
+	return c.request(ctx, http.MethodDelete, endpoint, nil)
 }
 
 func ParseData[T any](resp *Response) (T, error) {
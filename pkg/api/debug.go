@@ -0,0 +1,51 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+)
+
+// debugRequest logs an outgoing request to stderr for --debug.
+// The Authorization header is redacted.
+func debugRequest(req *http.Request, body interface{}) {
+	fmt.Fprintf(os.Stderr, "> %s %s\n", req.Method, req.URL)
+	debugHeaders(">", req.Header)
+	if body != nil {
+		jsonBody, err := json.MarshalIndent(body, "", "  ")
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "%s\n", jsonBody)
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// debugResponse logs a response status, headers and body to stderr for --debug
+func debugResponse(resp *http.Response, body []byte) {
+	fmt.Fprintf(os.Stderr, "< %s %s\n", resp.Proto, resp.Status)
+	debugHeaders("<", resp.Header)
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, body, "", "  ") == nil {
+		body = pretty.Bytes()
+	}
+	fmt.Fprintf(os.Stderr, "%s\n\n", body)
+}
+
+func debugHeaders(prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if name == "Authorization" {
+				value = "[redacted]"
+			}
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", prefix, name, value)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Endpoint formats an API path like fmt.Sprintf, but path-escapes every
+// string argument so user input such as "a/b" stays a single path segment.
+// Non-string arguments (IDs) are formatted as-is.
+func Endpoint(format string, args ...interface{}) string {
+	escaped := make([]interface{}, len(args))
+	for i, arg := range args {
+		if s, ok := arg.(string); ok {
+			escaped[i] = url.PathEscape(s)
+		} else {
+			escaped[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, escaped...)
+}
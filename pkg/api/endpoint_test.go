@@ -0,0 +1,29 @@
+package api
+
+import "testing"
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		args   []interface{}
+		want   string
+	}{
+		{"plain string", "/v1/cloud/servers/%s", []interface{}{"abc"}, "/v1/cloud/servers/abc"},
+		{"slash", "/v1/cloud/servers/%s", []interface{}{"a/b"}, "/v1/cloud/servers/a%2Fb"},
+		{"question mark", "/v1/cloud/servers/%s", []interface{}{"a?b=1"}, "/v1/cloud/servers/a%3Fb=1"},
+		{"percent", "/v1/cloud/servers/%s", []interface{}{"100%"}, "/v1/cloud/servers/100%25"},
+		{"space", "/v1/cloud/servers/%s", []interface{}{"my server"}, "/v1/cloud/servers/my%20server"},
+		{"traversal", "/v1/cloud/servers/%s/reports", []interface{}{"../admin"}, "/v1/cloud/servers/..%2Fadmin/reports"},
+		{"int", "/v1/cdn/ng/domains/%d/dns/%d", []interface{}{42, 7}, "/v1/cdn/ng/domains/42/dns/7"},
+		{"int and string", "/v1/cdn/ng/domains/%d/cache/%s", []interface{}{42, "a b/c"}, "/v1/cdn/ng/domains/42/cache/a%20b%2Fc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Endpoint(tt.format, tt.args...); got != tt.want {
+				t.Errorf("Endpoint(%q, %v) = %q, want %q", tt.format, tt.args, got, tt.want)
+			}
+		})
+	}
+}
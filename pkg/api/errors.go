@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned by the client when the API rejects a request
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Errors holds field-level validation messages, keyed by field name
+	Errors map[string]string
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) > 0 {
+		return e.validationError()
+	}
+
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "unauthorized: please login again using 'mizban login'"
+	case http.StatusTooManyRequests:
+		return "rate limited: please wait and try again (retries are set with --max-retries)"
+	}
+
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("API error: %s", msg)
+}
+
+func (e *APIError) validationError() string {
+	var b strings.Builder
+	if e.Message != "" {
+		fmt.Fprintf(&b, "API error: %s\n", e.Message)
+	}
+	b.WriteString("Validation errors:")
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		fmt.Fprintf(&b, "\n  %s: %s", field, e.Errors[field])
+	}
+	return b.String()
+}
+
+// IsNotFound reports whether err is an API error with status 404
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// HasStatus reports whether err is an API error with the given HTTP status
+func HasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// newAPIError builds the error for an unsuccessful API response
+func newAPIError(status int, resp ErrorResponse) *APIError {
+	apiErr := &APIError{StatusCode: status, Message: resp.Message}
+	if len(resp.Errors) > 0 {
+		apiErr.Errors = make(map[string]string, len(resp.Errors))
+		for field, msg := range resp.Errors {
+			apiErr.Errors[field] = msg.String()
+		}
+	}
+	return apiErr
+}
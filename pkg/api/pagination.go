@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mizbancloud/cli/pkg/output"
+)
+
+// allPagesPerPage is the page size used when walking every page.
+const allPagesPerPage = 100
+
+// Meta carries the pagination details of list responses
+type Meta struct {
+	CurrentPage int `json:"current_page"`
+	PerPage     int `json:"per_page"`
+	Total       int `json:"total"`
+	LastPage    int `json:"last_page"`
+}
+
+// Footer summarises which items of the full list were shown,
+// e.g. "Showing 1-20 of 137".
+func (m *Meta) Footer(shown int) string {
+	if shown == 0 {
+		return fmt.Sprintf("Showing 0 of %d", m.Total)
+	}
+	from := (m.CurrentPage-1)*m.PerPage + 1
+	if from < 1 {
+		from = 1
+	}
+	return fmt.Sprintf("Showing %d-%d of %d", from, from+shown-1, m.Total)
+}
+
+// PageOptions selects which page of a list endpoint to fetch. Zero values
+// leave the choice to the API.
+type PageOptions struct {
+	Page    int
+	PerPage int
+	All     bool
+}
+
+func (o PageOptions) Validate() error {
+	if o.Page < 0 {
+		return fmt.Errorf("invalid --page %d: must be a positive number", o.Page)
+	}
+	if o.PerPage < 0 {
+		return fmt.Errorf("invalid --per-page %d: must be a positive number", o.PerPage)
+	}
+	if o.All && o.Page > 0 {
+		return fmt.Errorf("--all and --page cannot be used together")
+	}
+	return nil
+}
+
+// pageEndpoint appends page and per_page query parameters to endpoint
+func pageEndpoint(endpoint string, page, perPage int) string {
+	var params []string
+	if page > 0 {
+		params = append(params, fmt.Sprintf("page=%d", page))
+	}
+	if perPage > 0 {
+		params = append(params, fmt.Sprintf("per_page=%d", perPage))
+	}
+	if len(params) == 0 {
+		return endpoint
+	}
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return endpoint + sep + strings.Join(params, "&")
+}
+
+// GetList fetches a paginated list endpoint. With opts.All it follows the
+// response metadata through every page and returns all items together.
+// The returned Meta is nil when the API did not paginate the response.
+func GetList[T any](c *Client, endpoint string, opts PageOptions) ([]T, *Meta, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if !opts.All {
+		resp, err := c.Get(pageEndpoint(endpoint, opts.Page, opts.PerPage))
+		if err != nil {
+			return nil, nil, err
+		}
+		var items []T
+		if err := json.Unmarshal(resp.Data, &items); err != nil {
+			return nil, nil, fmt.Errorf("error parsing data: %w", err)
+		}
+		return items, resp.Meta, nil
+	}
+
+	perPage := opts.PerPage
+	if perPage == 0 {
+		perPage = allPagesPerPage
+	}
+
+	var all []T
+	var progress *output.Progress
+	defer func() {
+		if progress != nil {
+			progress.Done()
+		}
+	}()
+	for page := 1; ; page++ {
+		if progress != nil {
+			progress.Step(fmt.Sprintf("fetching page %d", page))
+		}
+		resp, err := c.Get(pageEndpoint(endpoint, page, perPage))
+		if err != nil {
+			return nil, nil, err
+		}
+		if progress == nil && resp.Meta != nil {
+			// The page count is only known once the first page is in
+			progress = output.NewProgress(resp.Meta.LastPage)
+			progress.Step("fetched page 1")
+		}
+		var items []T
+		if err := json.Unmarshal(resp.Data, &items); err != nil {
+			return nil, nil, fmt.Errorf("error parsing data: %w", err)
+		}
+		all = append(all, items...)
+
+		if resp.Meta == nil || len(items) == 0 || resp.Meta.CurrentPage >= resp.Meta.LastPage {
+			break
+		}
+	}
+
+	meta := &Meta{CurrentPage: 1, PerPage: len(all), Total: len(all), LastPage: 1}
+	return all, meta, nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mizbancloud/cli/pkg/output"
+)
+
+// DefaultParallelism is how many requests ParallelGet keeps in flight when
+// no limit is given
+const DefaultParallelism = 4
+
+// GetResult is the outcome of one ParallelGet request
+type GetResult struct {
+	Endpoint string
+	Response *Response
+	Err      error
+}
+
+// ParallelGet fetches endpoints with at most limit requests in flight
+// (DefaultParallelism if limit is 0 or less). Results are in the order of
+// endpoints. A failed request doesn't stop the others; the returned error
+// joins every failure, each prefixed with its endpoint, and is nil when all
+// succeeded. Once the client's context is cancelled no new requests start
+// and the remaining results carry the context's error. A progress bar
+// counts completed requests.
+func (c *Client) ParallelGet(endpoints []string, limit int) ([]GetResult, error) {
+	if limit <= 0 {
+		limit = DefaultParallelism
+	}
+
+	results := make([]GetResult, len(endpoints))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	progress := output.NewProgress(len(endpoints))
+	defer progress.Done()
+	for i, endpoint := range endpoints {
+		results[i].Endpoint = endpoint
+
+		select {
+		case sem <- struct{}{}:
+		case <-c.ctx.Done():
+			results[i].Err = c.ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *GetResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r.Response, r.Err = c.Get(r.Endpoint)
+			mu.Lock()
+			progress.Step(r.Endpoint)
+			mu.Unlock()
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Endpoint, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mizbancloud/cli/pkg/config"
+)
+
+func newTestClient(baseURL string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		config:     &config.Config{BaseURL: baseURL},
+		ctx:        context.Background(),
+	}
+}
+
+func TestParallelGet(t *testing.T) {
+	const limit = 3
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		// Hold each request long enough for the others to pile up
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/fail/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"success":false,"message":"boom %s"}`, strings.TrimPrefix(r.URL.Path, "/fail/"))
+			return
+		}
+		fmt.Fprintf(w, `{"success":true,"data":%q}`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	var endpoints []string
+	for i := 0; i < 10; i++ {
+		if i == 2 || i == 7 {
+			endpoints = append(endpoints, fmt.Sprintf("/fail/%d", i))
+			continue
+		}
+		endpoints = append(endpoints, fmt.Sprintf("/ok/%d", i))
+	}
+
+	results, err := newTestClient(srv.URL).ParallelGet(endpoints, limit)
+
+	if maxInFlight > limit {
+		t.Errorf("max in flight = %d, want at most %d", maxInFlight, limit)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("max in flight = %d, requests don't seem to run concurrently", maxInFlight)
+	}
+
+	if len(results) != len(endpoints) {
+		t.Fatalf("got %d results, want %d", len(results), len(endpoints))
+	}
+	for i, r := range results {
+		if r.Endpoint != endpoints[i] {
+			t.Errorf("results[%d].Endpoint = %s, want %s", i, r.Endpoint, endpoints[i])
+		}
+		if strings.HasPrefix(endpoints[i], "/fail/") {
+			if r.Err == nil {
+				t.Errorf("results[%d]: want an error", i)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("results[%d]: unexpected error %v", i, r.Err)
+			continue
+		}
+		if want := fmt.Sprintf("%q", endpoints[i]); string(r.Response.Data) != want {
+			t.Errorf("results[%d].Response.Data = %s, want %s", i, r.Response.Data, want)
+		}
+	}
+
+	if err == nil {
+		t.Fatal("want a joined error for the failed endpoints")
+	}
+	for _, want := range []string{"/fail/2: API error: boom 2", "/fail/7: API error: boom 7"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "/ok/") {
+		t.Errorf("error %q names an endpoint that succeeded", err)
+	}
+}
+
+func TestParallelGetAllSucceed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"data":{}}`)
+	}))
+	defer srv.Close()
+
+	results, err := newTestClient(srv.URL).ParallelGet([]string{"/a", "/b"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+}
+
+func TestParallelGetCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"data":{}}`)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := newTestClient(srv.URL).WithContext(ctx).ParallelGet([]string{"/a", "/b", "/c"}, 1)
+	if err == nil {
+		t.Fatal("want an error from a cancelled context")
+	}
+	for i, r := range results {
+		if r.Err == nil {
+			t.Errorf("results[%d]: want an error after cancellation", i)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRetryWait caps how long a single Retry-After pause can be
+const maxRetryWait = 60 * time.Second
+
+// retryDelay works out how long to wait before retrying a rate-limited
+// request. Retry-After may be a number of seconds or an HTTP date; without
+// it the delay doubles with each attempt, starting at one second.
+func retryDelay(header http.Header, attempt int, now time.Time) time.Duration {
+	delay := time.Second << attempt
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+			delay = time.Duration(secs) * time.Second
+		} else if at, err := http.ParseTime(value); err == nil {
+			delay = at.Sub(now)
+		}
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryWait {
+		delay = maxRetryWait
+	}
+	return delay
+}
+
+// sleepCtx waits for d, returning early with the context's error if it is
+// cancelled
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/util"
+)
+
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change CLI configuration",
+		Long:  fmt.Sprintf("View and change CLI settings stored in the config file.\n\nKeys: %s", strings.Join(config.Keys(), ", ")),
+	}
+
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigViewCmd())
+
+	return cmd
+}
+
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Print a config value",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := config.GetConfig().Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Set and save a config value",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.GetConfig()
+			if err := cfg.Set(args[0], args[1]); err != nil {
+				return fmt.Errorf("failed to set %s: %w", args[0], err)
+			}
+
+			output.Infof("%s updated in %s\n", args[0], config.Path())
+			if cfg.FromEnv(args[0]) {
+				fmt.Fprintf(os.Stderr, "Note: the environment still overrides %s for this shell\n", args[0])
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigViewCmd() *cobra.Command {
+	var showSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show all config values",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.GetConfig()
+
+			fmt.Printf("# %s\n", config.Path())
+			for _, key := range config.Keys() {
+				value, _ := cfg.Get(key)
+				if config.IsSecret(key) && !showSecrets {
+					value = util.MaskSecret(value)
+				}
+				if cfg.FromEnv(key) {
+					value += "  (from environment)"
+				}
+				fmt.Printf("%s: %s\n", key, value)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show secret values such as the token unmasked")
+
+	return cmd
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/mizbancloud/cli/pkg/api"
 	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
 )
 
 func NewLoginCmd() *cobra.Command {
@@ -87,11 +89,62 @@ func NewLogoutCmd() *cobra.Command {
 		Long:  "Clear saved credentials and logout from MizbanCloud.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := config.GetConfig()
+			fromEnv := cfg.TokenFromEnv()
 			if err := cfg.Logout(); err != nil {
 				return fmt.Errorf("failed to logout: %w", err)
 			}
 			fmt.Println("Successfully logged out")
+			if fromEnv {
+				fmt.Fprintf(os.Stderr, "Note: %s is still set and will be used by later commands\n", config.EnvToken)
+			}
 			return nil
 		},
 	}
 }
+
+func NewWhoamiCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the account the current token belongs to",
+		Long:  "Print the name and email of the authenticated account and the API it talks to. Exits non-zero when not authenticated.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.GetConfig()
+			if !cfg.IsLoggedIn() {
+				return fmt.Errorf("not logged in: run 'mizban login' or set %s", config.EnvToken)
+			}
+
+			client := api.NewClient()
+			resp, err := client.Get("/v1/auth/profile")
+			if err != nil {
+				return err
+			}
+
+			var profile Profile
+			if err := json.Unmarshal(resp.Data, &profile); err != nil {
+				return fmt.Errorf("failed to parse profile: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(map[string]interface{}{
+					"name":           profile.Name,
+					"email":          profile.Email,
+					"base_url":       cfg.BaseURL,
+					"token_from_env": cfg.TokenFromEnv(),
+				})
+			}
+
+			source := "config"
+			if cfg.TokenFromEnv() {
+				source = config.EnvToken
+			}
+			fmt.Printf("%s <%s> on %s (token from %s)\n", profile.Name, profile.Email, cfg.BaseURL, source)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
@@ -3,11 +3,16 @@ package auth
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type Profile struct {
@@ -19,6 +24,22 @@ type Profile struct {
 	TFAEnabled  bool   `json:"tfa_enabled"`
 }
 
+// APIKey is an API token of the account. Keys without scopes have full
+// access; keys without an expiry never expire.
+type APIKey struct {
+	ID        int                       `json:"id"`
+	Name      string                    `json:"name"`
+	Token     string                    `json:"token"`
+	Scopes    types.FlexibleStringSlice `json:"scopes"`
+	ExpiresAt types.Timestamp           `json:"expires_at"`
+	CreatedAt string                    `json:"created_at"`
+}
+
+// expired reports whether the key's expiry has passed
+func (k APIKey) expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !k.ExpiresAt.Time().After(now)
+}
+
 func NewProfileCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "profile",
@@ -52,15 +73,13 @@ func newProfileShowCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(profile, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(profile)
 			}
 
 			fmt.Printf("Name:        %s\n", profile.Name)
 			fmt.Printf("Email:       %s\n", profile.Email)
 			fmt.Printf("Phone:       %s\n", profile.PhoneNumber)
-			fmt.Printf("2FA Enabled: %v\n", profile.TFAEnabled)
+			fmt.Printf("2FA Enabled: %s\n", types.FormatBool(profile.TFAEnabled))
 
 			return nil
 		},
@@ -97,7 +116,7 @@ func newProfileUpdateCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Profile updated successfully")
+			output.Infoln("Profile updated successfully")
 			return nil
 		},
 	}
@@ -122,9 +141,12 @@ func newAPIKeysCmd() *cobra.Command {
 }
 
 func newAPIKeyListCmd() *cobra.Command {
-	return &cobra.Command{
+	var jsonOutput, showSecrets bool
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List API keys",
+		Long:  "List the account's API keys. Tokens are masked unless --show-secrets is given.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
 			resp, err := client.Get("/v1/auth/api-token")
@@ -132,69 +154,193 @@ func newAPIKeyListCmd() *cobra.Command {
 				return err
 			}
 
-			var keys []struct {
-				ID        int    `json:"id"`
-				Name      string `json:"name"`
-				Token     string `json:"token"`
-				CreatedAt string `json:"created_at"`
-			}
+			var keys []APIKey
 			if err := json.Unmarshal(resp.Data, &keys); err != nil {
 				return fmt.Errorf("failed to parse keys: %w", err)
 			}
+			if !showSecrets {
+				for i := range keys {
+					keys[i].Token = util.MaskSecret(keys[i].Token)
+				}
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(keys)
+			}
 
 			if len(keys) == 0 {
 				fmt.Println("No API keys found")
 				return nil
 			}
 
-			fmt.Printf("%-5s %-20s %-40s %-20s\n", "ID", "NAME", "TOKEN", "CREATED")
-			fmt.Println(strings.Repeat("-", 90))
+			now := time.Now()
+			table := output.NewTable("ID", "NAME", "TOKEN", "SCOPES", "EXPIRES", "STATUS", "CREATED")
 			for _, key := range keys {
-				fmt.Printf("%-5d %-20s %-40s %-20s\n", key.ID, key.Name, key.Token[:20]+"...", key.CreatedAt)
+				scopes := "all"
+				if len(key.Scopes) > 0 {
+					scopes = key.Scopes.String()
+				}
+				expires, status := "never", "active"
+				if key.ExpiresAt.String() != "" {
+					expires = key.ExpiresAt.String()
+				}
+				if key.expired(now) {
+					status = "expired"
+				}
+				table.AddRow(key.ID, key.Name, key.Token, scopes, expires, status, key.CreatedAt)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show tokens unmasked")
+
+	return cmd
 }
 
 func newAPIKeyCreateCmd() *cobra.Command {
-	var name string
+	var name, expiresIn string
+	var scopes []string
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create new API key",
+		Long: `Create an API key. Restrict it with --scopes, a list of <area>:<access>
+pairs where area is cdn, cloud or ticket and access is read or write (e.g.
+cdn:read,cloud:write), and limit its lifetime with --expires-in (e.g. 90d, 2w
+or 12h). Without them the key has full access and never expires.
+
+If the API creates the key without the requested scopes or expiry, the key is
+deleted again and the command fails rather than leave an unrestricted key.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if name == "" {
 				return fmt.Errorf("name is required")
 			}
 
-			client := api.NewClient()
-			resp, err := client.Post("/v1/auth/api-token", map[string]string{"name": name})
+			body := map[string]interface{}{"name": name}
+			scopes, err := normalizeAPIKeyScopes(scopes)
 			if err != nil {
 				return err
 			}
+			if len(scopes) > 0 {
+				body["scopes"] = scopes
+			}
+			if expiresIn != "" {
+				lifetime, err := parseExpiresIn(expiresIn)
+				if err != nil {
+					return err
+				}
+				body["expires_at"] = time.Now().Add(lifetime).UTC().Format(time.RFC3339)
+			}
 
-			var key struct {
-				Token string `json:"token"`
+			client := api.NewClient()
+			resp, err := client.Post("/v1/auth/api-token", body)
+			if err != nil {
+				return err
 			}
+
+			var key APIKey
 			if err := json.Unmarshal(resp.Data, &key); err != nil {
 				return fmt.Errorf("failed to parse response: %w", err)
 			}
 
-			fmt.Printf("API key created successfully!\nToken: %s\n", key.Token)
-			fmt.Println("\nWarning: Save this token now. You won't be able to see it again!")
+			var missing string
+			switch {
+			case len(scopes) > 0 && len(key.Scopes) == 0:
+				missing = "scopes"
+			case expiresIn != "" && key.ExpiresAt.String() == "":
+				missing = "an expiry"
+			}
+			if missing != "" {
+				if key.ID == 0 {
+					return fmt.Errorf("the API created the key without %s, so it is unrestricted; delete it (see 'mizban profile api-keys list')", missing)
+				}
+				if _, err := client.Delete(api.Endpoint("/v1/auth/api-token/%d", key.ID)); err != nil {
+					return fmt.Errorf("the API created key %d without %s and it could not be deleted: %w; delete it by hand", key.ID, missing, err)
+				}
+				return fmt.Errorf("the API does not support API keys with %s; the unrestricted key it created has been deleted", missing)
+			}
+
+			output.Infof("API key created successfully!\nToken: %s\n", key.Token)
+			output.PrintID(key.Token)
+			if len(key.Scopes) > 0 {
+				output.Infof("Scopes: %s\n", key.Scopes)
+			}
+			if key.ExpiresAt.String() != "" {
+				output.Infof("Expires: %s\n", key.ExpiresAt)
+			}
+			output.Infoln("\nWarning: Save this token now. You won't be able to see it again!")
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Name for the API key")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", nil, "Restrict the key to these scopes (e.g. cdn:read,cloud:write)")
+	cmd.Flags().StringVar(&expiresIn, "expires-in", "", "Expire the key after this long (e.g. 90d, 2w, 12h)")
 	cmd.MarkFlagRequired("name")
 
 	return cmd
 }
 
+// apiKeyScopeAreas and apiKeyScopeAccess make up the <area>:<access>
+// scopes accepted by api-keys create
+var (
+	apiKeyScopeAreas  = []string{"cdn", "cloud", "ticket"}
+	apiKeyScopeAccess = []string{"read", "write"}
+)
+
+// normalizeAPIKeyScopes lowercases scopes, drops duplicates and checks each
+// is a known <area>:<access> pair
+func normalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		area, access, ok := strings.Cut(scope, ":")
+		if !ok || !contains(apiKeyScopeAreas, area) || !contains(apiKeyScopeAccess, access) {
+			return nil, fmt.Errorf("invalid scope %q: must be <area>:<access> with area %s and access %s",
+				scope, strings.Join(apiKeyScopeAreas, "/"), strings.Join(apiKeyScopeAccess, "/"))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// parseExpiresIn parses a key lifetime: a number of days ("90d") or weeks
+// ("2w"), or a Go duration such as "12h"
+func parseExpiresIn(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	d, err := time.ParseDuration(s)
+	if unit := strings.TrimLeft(s, "0123456789"); unit == "d" || unit == "w" {
+		var count int
+		count, err = strconv.Atoi(strings.TrimSuffix(s, unit))
+		d = time.Duration(count) * 24 * time.Hour
+		if unit == "w" {
+			d *= 7
+		}
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --expires-in %q: must be a positive duration like 90d, 2w or 12h", s)
+	}
+	return d, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func newAPIKeyDeleteCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete [key-id]",
@@ -202,12 +348,16 @@ func newAPIKeyDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete("/v1/auth/api-token/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/auth/api-token/%s", args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("API key already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("API key deleted successfully")
+			output.Infoln("API key deleted successfully")
 			return nil
 		},
 	}
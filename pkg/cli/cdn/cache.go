@@ -2,12 +2,21 @@ package cdn
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
 	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type CacheSettings struct {
@@ -25,6 +34,24 @@ type CacheSettings struct {
 	ImageOptimization types.NumericBool `json:"image_optimization"`
 }
 
+// PurgeJob tracks an asynchronous cache purge
+type PurgeJob struct {
+	ID       types.FlexibleString `json:"job_id"`
+	Status   string               `json:"status"`
+	Progress int                  `json:"progress"`
+}
+
+// purgeDone reports whether a purge job has finished, and whether it failed
+func purgeDone(status string) (done, failed bool) {
+	switch strings.ToLower(status) {
+	case "completed", "complete", "done", "finished", "success":
+		return true, false
+	case "failed", "error":
+		return true, true
+	}
+	return false, false
+}
+
 func NewCacheCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cache",
@@ -34,6 +61,7 @@ func NewCacheCmd() *cobra.Command {
 
 	cmd.AddCommand(newCacheStatusCmd())
 	cmd.AddCommand(newCachePurgeCmd())
+	cmd.AddCommand(newCachePurgeStatusCmd())
 	cmd.AddCommand(newCacheModeCmd())
 	cmd.AddCommand(newCacheDeveloperModeCmd())
 	cmd.AddCommand(newCacheAlwaysOnlineCmd())
@@ -52,14 +80,13 @@ func newCacheStatusCmd() *cobra.Command {
 		Short: "Get cache settings",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cache", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var settings CacheSettings
@@ -72,24 +99,24 @@ func newCacheStatusCmd() *cobra.Command {
 			fmt.Printf("Edge Cache:\n")
 			fmt.Printf("  Mode:           %s\n", settings.CacheMode)
 			fmt.Printf("  TTL:            %d seconds\n", settings.CacheTTL)
-			fmt.Printf("  Developer Mode: %v\n", settings.DeveloperMode.Bool())
-			fmt.Printf("  Always Online:  %v\n", settings.AlwaysOnline.Bool())
-			fmt.Printf("  Cache Cookies:  %v\n", settings.CacheCookies.Bool())
+			fmt.Printf("  Developer Mode: %s\n", settings.DeveloperMode)
+			fmt.Printf("  Always Online:  %s\n", settings.AlwaysOnline)
+			fmt.Printf("  Cache Cookies:  %s\n", settings.CacheCookies)
 			fmt.Printf("\nBrowser Cache:\n")
 			fmt.Printf("  Mode:           %s\n", settings.BrowserCacheMode)
 			fmt.Printf("  TTL:            %d seconds\n", settings.BrowserCacheTTL)
 			fmt.Printf("\nError Cache TTL:  %d seconds\n", settings.ErrorsCacheTTL)
 			fmt.Printf("\nMinification:\n")
-			fmt.Printf("  HTML:           %v\n", settings.MinifyHTML.Bool())
-			fmt.Printf("  CSS:            %v\n", settings.MinifyCSS.Bool())
-			fmt.Printf("  JS:             %v\n", settings.MinifyJS.Bool())
-			fmt.Printf("\nImage Optimization: %v\n", settings.ImageOptimization.Bool())
+			fmt.Printf("  HTML:           %s\n", settings.MinifyHTML)
+			fmt.Printf("  CSS:            %s\n", settings.MinifyCSS)
+			fmt.Printf("  JS:             %s\n", settings.MinifyJS)
+			fmt.Printf("\nImage Optimization: %s\n", settings.ImageOptimization)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -109,19 +136,19 @@ func newCacheModeCmd() *cobra.Command {
   - no-cache:   Disable caching`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/edge/change-mode", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/change-mode", domainID), map[string]interface{}{
 				"mode": mode,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Cache mode set to: %s\n", mode)
+			output.Infof("Cache mode set to: %s\n", mode)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&mode, "mode", "aggressive", "Cache mode (standard/aggressive/no-cache)")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("mode")
@@ -139,7 +166,7 @@ func newCacheAlwaysOnlineCmd() *cobra.Command {
 		Long:  "When enabled, serves cached content when origin is unavailable.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/edge/always-online", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/always-online", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -147,15 +174,15 @@ func newCacheAlwaysOnlineCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("Always online mode enabled")
+				output.Infoln("Always online mode enabled")
 			} else {
-				fmt.Println("Always online mode disabled")
+				output.Infoln("Always online mode disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable always online")
 	cmd.MarkFlagRequired("domain")
 
@@ -172,7 +199,7 @@ func newCacheCookiesCmd() *cobra.Command {
 		Long:  "When enabled, caches content even when cookies are present.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/edge/cache-cookies", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/cache-cookies", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -180,67 +207,306 @@ func newCacheCookiesCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("Cookie caching enabled")
+				output.Infoln("Cookie caching enabled")
 			} else {
-				fmt.Println("Cookie caching disabled")
+				output.Infoln("Cookie caching disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable cookie caching")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
+// purgeBatchSize is the most URLs or prefixes the API accepts in one purge
+// request; longer lists are sent in several requests
+const purgeBatchSize = 100
+
 func newCachePurgeCmd() *cobra.Command {
 	var domainID int
-	var urls []string
-	var all bool
+	var urls, prefixes, tags []string
+	var all, wait, force bool
+	var waitTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "purge",
 		Short: "Purge cached content",
+		Long: `Purge cached content. Purges run asynchronously; use --wait to block until the purge job completes.
+
+--url purges exact URLs only; wildcards are not supported there. To purge
+everything under a path use --prefix, e.g. --prefix /assets/ (a trailing *
+as in /assets/* is accepted and means the same). Lists longer than 100
+entries are sent in several requests.
+
+--tag purges every object the origin labelled with that cache tag (sent in
+a Cache-Tag response header), e.g. all content of one deploy.
+
+Purging everything with --all asks for confirmation unless --force is given.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-
-			body := map[string]interface{}{
-				"domain_id": domainID,
+			dryRun := config.GetConfig().DryRun
+
+			var key, noun string
+			var items []string
+			switch {
+			case all:
+				if !force && !dryRun {
+					ok, err := prompt.Confirm(fmt.Sprintf("Purge ALL cached content for domain %d?", domainID))
+					if err != nil {
+						return err
+					}
+					if !ok {
+						return prompt.ErrAborted
+					}
+				}
+			case len(urls) > 0:
+				for _, u := range urls {
+					if err := validatePurgeURL(u); err != nil {
+						return err
+					}
+				}
+				key, noun, items = "urls", "URL(s)", urls
+			case len(prefixes) > 0:
+				for i, p := range prefixes {
+					normalized, err := normalizePurgePrefix(p)
+					if err != nil {
+						return err
+					}
+					prefixes[i] = normalized
+				}
+				key, noun, items = "prefixes", "prefix(es)", prefixes
+			case len(tags) > 0:
+				for _, t := range tags {
+					if err := validateCacheTag(t); err != nil {
+						return err
+					}
+				}
+				key, noun, items = "tags", "tag(s)", tags
+			default:
+				return fmt.Errorf("specify --all, --url, --prefix or --tag")
 			}
 
 			if all {
-				body["purge_all"] = true
-			} else if len(urls) > 0 {
-				body["urls"] = urls
-			} else {
-				return fmt.Errorf("specify --all or --url")
+				if dryRun {
+					output.Infof("All cached content for domain %d would be purged\n", domainID)
+				}
+				job, err := startPurge(client, domainID, map[string]interface{}{"purge_all": true}, wait)
+				if err != nil {
+					return err
+				}
+				output.Infoln("Purge of all cache started")
+				return reportPurgeJobs(client, domainID, []PurgeJob{job}, wait, waitTimeout)
 			}
 
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/edge/purge-cache", domainID), body)
-			if err != nil {
-				return err
+			output.Infof("Purging %d %s:\n", len(items), noun)
+			for _, item := range items {
+				output.Infof("  - %s\n", item)
 			}
 
-			if all {
-				fmt.Println("All cache purged successfully")
-			} else {
-				fmt.Printf("Purged %d URL(s) successfully\n", len(urls))
+			var jobs []PurgeJob
+			progress := output.NewProgress((len(items) + purgeBatchSize - 1) / purgeBatchSize)
+			for start := 0; start < len(items); start += purgeBatchSize {
+				end := start + purgeBatchSize
+				if end > len(items) {
+					end = len(items)
+				}
+				progress.Step(fmt.Sprintf("%s %d-%d", noun, start+1, end))
+				job, err := startPurge(client, domainID, map[string]interface{}{key: items[start:end]}, wait)
+				progress.Clear()
+				if err != nil && key == "tags" && purgeUnsupported(err) {
+					return fmt.Errorf("purging by cache tag is not supported for domain %d: %w", domainID, err)
+				}
+				if err != nil {
+					if start > 0 {
+						return fmt.Errorf("purge stopped after %d of %d %s: %w", start, len(items), noun, err)
+					}
+					return err
+				}
+				jobs = append(jobs, job)
 			}
-			return nil
+			output.Infof("Purge of %d %s started\n", len(items), noun)
+			return reportPurgeJobs(client, domainID, jobs, wait, waitTimeout)
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringSliceVar(&urls, "url", nil, "URLs to purge (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&prefixes, "prefix", nil, "Purge every URL under these paths, e.g. /assets/ (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Purge everything labelled with these cache tags (can be specified multiple times)")
 	cmd.Flags().BoolVar(&all, "all", false, "Purge all cache")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the purge job completes")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait with --wait (0 waits forever)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation for --all")
 
 	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagsMutuallyExclusive("all", "url", "prefix", "tag")
 
 	return cmd
 }
 
+// startPurge sends one purge request. The job is only needed for --wait;
+// some responses carry none.
+func startPurge(client *api.Client, domainID int, body map[string]interface{}, wait bool) (PurgeJob, error) {
+	body["domain_id"] = domainID
+	var job PurgeJob
+	resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/purge-cache", domainID), body)
+	if err != nil {
+		return job, err
+	}
+	if err := json.Unmarshal(resp.Data, &job); err != nil && wait {
+		return job, fmt.Errorf("failed to parse purge job: %w", err)
+	}
+	return job, nil
+}
+
+// purgeUnsupported reports whether the API rejected a kind of purge it
+// doesn't implement. Validation errors (400, 422) are about the request
+// itself and are returned as they are.
+func purgeUnsupported(err error) bool {
+	return api.HasStatus(err, http.StatusNotFound) ||
+		api.HasStatus(err, http.StatusMethodNotAllowed) ||
+		api.HasStatus(err, http.StatusNotImplemented)
+}
+
+// validateCacheTag rejects tags that can't appear in a Cache-Tag header
+func validateCacheTag(s string) error {
+	if s == "" || strings.ContainsAny(s, " \t,") {
+		return fmt.Errorf("invalid --tag %q: tags can't be empty or contain spaces or commas", s)
+	}
+	return nil
+}
+
+// reportPurgeJobs prints the started jobs and, with --wait, waits for each
+func reportPurgeJobs(client *api.Client, domainID int, jobs []PurgeJob, wait bool, timeout time.Duration) error {
+	for _, job := range jobs {
+		if job.ID == "" {
+			continue
+		}
+		fmt.Printf("Job ID: %s\n", job.ID)
+
+		if !wait {
+			fmt.Printf("Check progress with: mizban cache purge-status %s --domain %d\n", job.ID, domainID)
+			continue
+		}
+
+		finished, err := waitForPurge(client, domainID, job.ID.String(), timeout)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Purge %s\n", strings.ToLower(finished.Status))
+	}
+	return nil
+}
+
+// validatePurgeURL accepts a path such as /img/logo.png or an absolute
+// http(s) URL. Purges by URL are exact, so wildcards are refused.
+func validatePurgeURL(s string) error {
+	if strings.Contains(s, "*") {
+		return fmt.Errorf("invalid --url %q: wildcards are not supported; use --prefix to purge everything under a path", s)
+	}
+	if strings.HasPrefix(s, "/") {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid --url %q: must be a path starting with / or an http(s) URL", s)
+	}
+	return nil
+}
+
+// normalizePurgePrefix checks a --prefix like validatePurgeURL does, turning
+// a trailing wildcard such as /assets/* into the plain prefix /assets/
+func normalizePurgePrefix(s string) (string, error) {
+	p := strings.TrimSuffix(s, "*")
+	if p == "" || strings.Contains(p, "*") {
+		return "", fmt.Errorf("invalid --prefix %q: only a trailing * is allowed", s)
+	}
+	if strings.HasPrefix(p, "/") {
+		return p, nil
+	}
+	u, err := url.Parse(p)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid --prefix %q: must be a path starting with / or an http(s) URL", s)
+	}
+	return p, nil
+}
+
+func newCachePurgeStatusCmd() *cobra.Command {
+	var domainID int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "purge-status [job-id]",
+		Short: "Show the progress of a cache purge",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/purge-cache/%s", domainID, args[0]))
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(resp.Data, true)
+			}
+
+			var job PurgeJob
+			if err := json.Unmarshal(resp.Data, &job); err != nil {
+				return fmt.Errorf("failed to parse purge job: %w", err)
+			}
+
+			fmt.Printf("Job ID:   %s\n", args[0])
+			fmt.Printf("Status:   %s\n", job.Status)
+			fmt.Printf("Progress: %d%%\n", job.Progress)
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+// waitForPurge polls a purge job until it completes or fails
+func waitForPurge(client *api.Client, domainID int, jobID string, timeout time.Duration) (*PurgeJob, error) {
+	line := output.NewStatusLine()
+	var job PurgeJob
+
+	err := util.Poll(client.Context(), timeout, 2*time.Second, func() (bool, error) {
+		resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/purge-cache/%s", domainID, jobID))
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(resp.Data, &job); err != nil {
+			return false, fmt.Errorf("failed to parse purge job: %w", err)
+		}
+
+		done, failed := purgeDone(job.Status)
+		if failed {
+			return false, fmt.Errorf("purge job %s failed", jobID)
+		}
+		if !done {
+			line.Update(fmt.Sprintf("Purging... %s (%d%%)", job.Status, job.Progress))
+		}
+		return done, nil
+	})
+	line.Done()
+
+	if errors.Is(err, util.ErrTimeout) {
+		return nil, fmt.Errorf("timed out after %s waiting for purge job %s (last status: %s)", timeout, jobID, job.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
 func newCacheSettingsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "settings",
@@ -265,7 +531,7 @@ func newCacheTTLCmd() *cobra.Command {
 		Short: "Set cache TTL",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/edge/change-ttl", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/change-ttl", domainID), map[string]interface{}{
 				"mode": mode,
 				"ttl":  ttl,
 			})
@@ -273,12 +539,12 @@ func newCacheTTLCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("Cache TTL set to %d seconds (mode: %s)\n", ttl, mode)
+			output.Infof("Cache TTL set to %d seconds (mode: %s)\n", ttl, mode)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&mode, "mode", "aggressive", "Cache mode (standard/aggressive/no-cache)")
 	cmd.Flags().IntVar(&ttl, "ttl", 86400, "TTL in seconds")
 
@@ -296,7 +562,7 @@ func newCacheBrowserCmd() *cobra.Command {
 		Short: "Set browser cache TTL",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/browser/change-mode", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/browser/change-mode", domainID), map[string]interface{}{
 				"mode": mode,
 				"ttl":  ttl,
 			})
@@ -304,12 +570,12 @@ func newCacheBrowserCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("Browser cache set (mode: %s, TTL: %d)\n", mode, ttl)
+			output.Infof("Browser cache set (mode: %s, TTL: %d)\n", mode, ttl)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&mode, "mode", "respect", "Mode (respect/override)")
 	cmd.Flags().IntVar(&ttl, "ttl", 86400, "TTL in seconds")
 
@@ -327,7 +593,7 @@ func newCacheMinifyCmd() *cobra.Command {
 		Short: "Configure minification",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/acceleration/assets/minify", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/acceleration/assets/minify", domainID), map[string]interface{}{
 				"html": html,
 				"css":  css,
 				"js":   js,
@@ -336,12 +602,12 @@ func newCacheMinifyCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Minification settings updated")
+			output.Infoln("Minification settings updated")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&html, "html", false, "Minify HTML")
 	cmd.Flags().BoolVar(&css, "css", false, "Minify CSS")
 	cmd.Flags().BoolVar(&js, "js", false, "Minify JavaScript")
@@ -359,19 +625,19 @@ func newCacheErrorsTTLCmd() *cobra.Command {
 		Short: "Set error responses cache TTL",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/errors/cache-ttl", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/errors/cache-ttl", domainID), map[string]interface{}{
 				"ttl": ttl,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Error responses cache TTL set to %d seconds\n", ttl)
+			output.Infof("Error responses cache TTL set to %d seconds\n", ttl)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&ttl, "ttl", 300, "TTL in seconds")
 	cmd.MarkFlagRequired("domain")
 
@@ -399,7 +665,7 @@ func newCacheImageWebpCmd() *cobra.Command {
 		Short: "Enable/disable WebP conversion",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/acceleration/images/optimize", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/acceleration/images/optimize", domainID), map[string]interface{}{
 				"webp": enabled,
 			})
 			if err != nil {
@@ -407,15 +673,15 @@ func newCacheImageWebpCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("WebP conversion enabled")
+				output.Infoln("WebP conversion enabled")
 			} else {
-				fmt.Println("WebP conversion disabled")
+				output.Infoln("WebP conversion disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable WebP")
 	cmd.MarkFlagRequired("domain")
 
@@ -431,7 +697,7 @@ func newCacheImageResizeCmd() *cobra.Command {
 		Short: "Enable/disable image resizing",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/acceleration/images/resize", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/acceleration/images/resize", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -439,15 +705,15 @@ func newCacheImageResizeCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("Image resizing enabled")
+				output.Infoln("Image resizing enabled")
 			} else {
-				fmt.Println("Image resizing disabled")
+				output.Infoln("Image resizing disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable image resizing")
 	cmd.MarkFlagRequired("domain")
 
@@ -463,7 +729,7 @@ func newCacheDeveloperModeCmd() *cobra.Command {
 		Short: "Enable/disable developer mode",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cache/edge/developer-mode", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cache/edge/developer-mode", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -471,15 +737,15 @@ func newCacheDeveloperModeCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("Developer mode enabled (cache bypassed)")
+				output.Infoln("Developer mode enabled (cache bypassed)")
 			} else {
-				fmt.Println("Developer mode disabled")
+				output.Infoln("Developer mode disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable developer mode")
 
 	cmd.MarkFlagRequired("domain")
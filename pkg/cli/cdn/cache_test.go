@@ -0,0 +1,32 @@
+package cdn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mizbancloud/cli/pkg/api"
+)
+
+func TestPurgeUnsupported(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&api.APIError{StatusCode: http.StatusNotFound}, true},
+		{&api.APIError{StatusCode: http.StatusMethodNotAllowed}, true},
+		{&api.APIError{StatusCode: http.StatusNotImplemented}, true},
+		{fmt.Errorf("wrapped: %w", &api.APIError{StatusCode: http.StatusNotFound}), true},
+		{&api.APIError{StatusCode: http.StatusBadRequest}, false},
+		{&api.APIError{StatusCode: http.StatusUnprocessableEntity}, false},
+		{&api.APIError{StatusCode: http.StatusInternalServerError}, false},
+		{errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		if got := purgeUnsupported(tt.err); got != tt.want {
+			t.Errorf("purgeUnsupported(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
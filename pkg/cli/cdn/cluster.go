@@ -3,11 +3,14 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
 	"github.com/mizbancloud/cli/pkg/types"
 )
 
@@ -15,14 +18,15 @@ type ClusterPool struct {
 	ID                      int               `json:"id"`
 	DomainID                int               `json:"domain_id"`
 	Name                    string            `json:"name"`
-	Port                    int               `json:"port"`
+	Port                    types.FlexibleInt `json:"port"`
 	Description             string            `json:"description"`
 	Method                  string            `json:"method"`
 	HashKey                 string            `json:"hash_key,omitempty"`
 	ErrorReporting          types.NumericBool `json:"error_reporting"`
 	MonitoringProtocol      string            `json:"monitoring_protocol,omitempty"`
-	MonitoringPort          int               `json:"monitoring_port,omitempty"`
+	MonitoringPort          types.FlexibleInt `json:"monitoring_port,omitempty"`
 	MonitoringMethod        string            `json:"monitoring_method,omitempty"`
+	MonitoringPath          string            `json:"monitoring_path,omitempty"`
 	MonitoringErrorReporting types.NumericBool `json:"monitoring_error_reporting"`
 	Servers                 []ClusterServer   `json:"servers,omitempty"`
 	CreatedAt               string            `json:"created_at"`
@@ -30,14 +34,39 @@ type ClusterPool struct {
 }
 
 type ClusterServer struct {
-	ID         int    `json:"id"`
-	PoolID     int    `json:"pool_id"`
-	Address    string `json:"address"`
-	Weight     int    `json:"weight"`
-	HostHeader string `json:"host_header,omitempty"`
-	Port       int    `json:"port"`
-	Priority   int    `json:"priority"`
-	Protocol   string `json:"protocol"`
+	ID         int               `json:"id"`
+	PoolID     int               `json:"pool_id"`
+	Address    string            `json:"address"`
+	Weight     int               `json:"weight"`
+	HostHeader string            `json:"host_header,omitempty"`
+	Port       types.FlexibleInt `json:"port"`
+	Priority   int               `json:"priority"`
+	Protocol   string            `json:"protocol"`
+}
+
+// ClusterServerHealth is the latest monitoring result for a backend server
+type ClusterServerHealth struct {
+	ServerID     int               `json:"server_id"`
+	Address      string            `json:"address"`
+	Status       string            `json:"status"`
+	LastCheck    types.Timestamp   `json:"last_check"`
+	ResponseTime types.FlexibleInt `json:"response_time,omitempty"`
+	Message      string            `json:"message,omitempty"`
+}
+
+// monitoringProtocols are the health check protocols the API accepts
+var monitoringProtocols = []string{"HTTP", "HTTPS", "TCP"}
+
+// monitoringSummary renders the pool's health check settings, e.g. "http:8080"
+func (p ClusterPool) monitoringSummary() string {
+	if p.MonitoringProtocol == "" {
+		return "off"
+	}
+	monitoring := strings.ToLower(p.MonitoringProtocol)
+	if p.MonitoringPort > 0 {
+		monitoring = fmt.Sprintf("%s:%d", monitoring, p.MonitoringPort)
+	}
+	return monitoring
 }
 
 func NewClusterCmd() *cobra.Command {
@@ -53,6 +82,8 @@ func NewClusterCmd() *cobra.Command {
 	cmd.AddCommand(newClusterUpdateCmd())
 	cmd.AddCommand(newClusterDeleteCmd())
 	cmd.AddCommand(newClusterServerCmd())
+	cmd.AddCommand(newClusterHealthCmd())
+	cmd.AddCommand(newClusterMonitoringCmd())
 	cmd.AddCommand(newClusterAssignmentsCmd())
 	cmd.AddCommand(newClusterAssignCmd())
 	cmd.AddCommand(newClusterUnassignCmd())
@@ -69,7 +100,7 @@ func newClusterListCmd() *cobra.Command {
 		Short: "List cluster pools",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cluster", domainID))
 			if err != nil {
 				return err
 			}
@@ -80,9 +111,7 @@ func newClusterListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(pools, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(pools)
 			}
 
 			if len(pools) == 0 {
@@ -92,17 +121,9 @@ func newClusterListCmd() *cobra.Command {
 
 			for _, p := range pools {
 				fmt.Printf("Pool: %s (ID: %d)\n", p.Name, p.ID)
-				fmt.Printf("  Method: %-15s  Port: %-6d  Error Reporting: %v\n", p.Method, p.Port, p.ErrorReporting.Bool())
-
-				// Show monitoring status
-				monitoring := "off"
-				if p.MonitoringProtocol != "" {
-					monitoring = strings.ToLower(p.MonitoringProtocol)
-					if p.MonitoringPort > 0 {
-						monitoring = fmt.Sprintf("%s:%d", monitoring, p.MonitoringPort)
-					}
-				}
-				fmt.Printf("  Monitoring: %s\n", monitoring)
+				fmt.Printf("  Method: %-15s  Port: %-6d  Error Reporting: %s\n", p.Method, p.Port, p.ErrorReporting)
+
+				fmt.Printf("  Monitoring: %s\n", p.monitoringSummary())
 
 				if p.Description != "" {
 					fmt.Printf("  Description: %s\n", p.Description)
@@ -110,16 +131,16 @@ func newClusterListCmd() *cobra.Command {
 
 				if len(p.Servers) > 0 {
 					fmt.Println("  Servers:")
-					fmt.Printf("    %-8s %-25s %-8s %-8s %-10s %-10s\n", "ID", "ADDRESS", "PORT", "WEIGHT", "PROTOCOL", "STATUS")
-					fmt.Printf("    %s\n", strings.Repeat("-", 80))
+					table := output.NewTable("ID", "ADDRESS", "PORT", "WEIGHT", "PROTOCOL", "STATUS")
+					table.SetIndent("    ")
 					for _, s := range p.Servers {
 						status := "active"
 						if s.Priority == -1 {
 							status = "backup"
 						}
-						fmt.Printf("    %-8d %-25s %-8d %-8d %-10s %-10s\n",
-							s.ID, s.Address, s.Port, s.Weight, s.Protocol, status)
+						table.AddRow(s.ID, s.Address, s.Port, s.Weight, s.Protocol, status)
 					}
+					table.Render()
 				} else {
 					fmt.Println("  Servers: (none)")
 				}
@@ -130,7 +151,7 @@ func newClusterListCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -154,14 +175,13 @@ func newClusterAssignmentsCmd() *cobra.Command {
 		Long:  "List all cluster to path assignments for a domain.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/assignments", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/assignments", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var assignments []ClusterAssignment
@@ -174,18 +194,17 @@ func newClusterAssignmentsCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-12s %-20s %-10s %-30s\n", "CLUSTER ID", "CLUSTER NAME", "PATH ID", "PATH")
-			fmt.Println(strings.Repeat("-", 75))
+			table := output.NewTable("CLUSTER ID", "CLUSTER NAME", "PATH ID", "PATH")
 			for _, a := range assignments {
-				fmt.Printf("%-12d %-20s %-10d %-30s\n",
-					a.ClusterID, truncate(a.ClusterName, 20), a.PathID, truncate(a.Path, 30))
+				table.AddRow(a.ClusterID, a.ClusterName, a.PathID, a.Path)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -214,7 +233,7 @@ func newClusterAddCmd() *cobra.Command {
 				body["hash_key"] = hashKey
 			}
 
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster", domainID), body)
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cluster", domainID), body)
 			if err != nil {
 				return err
 			}
@@ -224,16 +243,17 @@ func newClusterAddCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse cluster: %w", err)
 			}
 
-			fmt.Printf("Cluster pool created successfully!\n")
-			fmt.Printf("ID: %d\n", pool.ID)
-			fmt.Printf("Name: %s\n", pool.Name)
-			fmt.Printf("Method: %s\n", pool.Method)
+			output.Infof("Cluster pool created successfully!\n")
+			output.Infof("ID: %d\n", pool.ID)
+			output.PrintID(pool.ID)
+			output.Infof("Name: %s\n", pool.Name)
+			output.Infof("Method: %s\n", pool.Method)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&name, "name", "", "Pool name")
 	cmd.Flags().IntVar(&port, "port", 443, "Backend port")
 	cmd.Flags().StringVar(&method, "method", "roundrobin", "Load balancing method (roundrobin/leastconn/iphash)")
@@ -269,17 +289,17 @@ func newClusterUpdateCmd() *cobra.Command {
 				body["hash_key"] = hashKey
 			}
 
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/%d", domainID, clusterID), body)
+			_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d", domainID, clusterID), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Cluster pool updated successfully")
+			output.Infoln("Cluster pool updated successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
 	cmd.Flags().StringVar(&name, "name", "", "Pool name")
 	cmd.Flags().IntVar(&port, "port", 443, "Backend port")
@@ -303,27 +323,31 @@ func newClusterDeleteCmd() *cobra.Command {
 		Short: "Delete a cluster pool",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete cluster %d? (yes/no): ", clusterID)
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete cluster %d?", clusterID))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/%d", domainID, clusterID))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d", domainID, clusterID))
+			if api.IsNotFound(err) {
+				fmt.Println("Cluster pool already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Cluster pool deleted successfully")
+			output.Infoln("Cluster pool deleted successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
 
@@ -333,6 +357,193 @@ func newClusterDeleteCmd() *cobra.Command {
 	return cmd
 }
 
+func newClusterHealthCmd() *cobra.Command {
+	var domainID, clusterID int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Show backend server health for a cluster",
+		Long:  "Show whether each backend server in a cluster is up or down, with the time of the last health check. If live results aren't available, the configured monitoring settings are shown instead.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			pool, err := getClusterPool(client, domainID, clusterID)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d/health", domainID, clusterID))
+			if api.IsNotFound(err) {
+				if jsonOutput {
+					return output.PrintJSONValue(pool)
+				}
+				fmt.Printf("Pool: %s (ID: %d)\n", pool.Name, pool.ID)
+				fmt.Printf("Monitoring: %s\n", pool.monitoringSummary())
+				if pool.MonitoringMethod != "" {
+					fmt.Printf("Method:     %s\n", pool.MonitoringMethod)
+				}
+				if len(pool.Servers) > 0 {
+					fmt.Println()
+					table := output.NewTable("SERVER ID", "ADDRESS", "PORT", "STATUS")
+					for _, server := range pool.Servers {
+						table.AddRow(server.ID, server.Address, server.Port, "unknown")
+					}
+					table.Render()
+				}
+				fmt.Println("\nLive health status isn't available for this cluster")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(resp.Data, true)
+			}
+
+			var health []ClusterServerHealth
+			if err := json.Unmarshal(resp.Data, &health); err != nil {
+				return fmt.Errorf("failed to parse health status: %w", err)
+			}
+
+			fmt.Printf("Pool: %s (ID: %d)\n", pool.Name, pool.ID)
+			fmt.Printf("Monitoring: %s\n\n", pool.monitoringSummary())
+
+			if len(health) == 0 {
+				fmt.Println("No health checks reported yet")
+				return nil
+			}
+
+			down := 0
+			table := output.NewTable("SERVER ID", "ADDRESS", "STATUS", "LAST CHECK", "RESPONSE (ms)", "MESSAGE")
+			for _, h := range health {
+				if !strings.EqualFold(h.Status, "up") {
+					down++
+				}
+				responseTime := "-"
+				if h.ResponseTime > 0 {
+					responseTime = h.ResponseTime.String()
+				}
+				table.AddRow(h.ServerID, h.Address, strings.ToUpper(h.Status), h.LastCheck, responseTime, h.Message)
+			}
+			table.Render()
+			fmt.Printf("\n%d of %d servers up\n", len(health)-down, len(health))
+
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("cluster")
+
+	return cmd
+}
+
+func newClusterMonitoringCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "monitoring",
+		Short: "Configure cluster health checks",
+	}
+
+	cmd.AddCommand(newClusterMonitoringSetCmd())
+
+	return cmd
+}
+
+func newClusterMonitoringSetCmd() *cobra.Command {
+	var domainID, clusterID, port int
+	var protocol, method, path string
+	var errorReporting bool
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set health check parameters for a cluster pool",
+		Long:  "Configure how backend servers in a cluster pool are health checked. Only the flags you pass are changed; the rest of the pool's settings are kept.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if !flags.Changed("protocol") && !flags.Changed("port") && !flags.Changed("method") &&
+				!flags.Changed("path") && !flags.Changed("error-reporting") {
+				return fmt.Errorf("no fields to update")
+			}
+			if flags.Changed("protocol") {
+				protocol = strings.ToUpper(protocol)
+				if !slices.Contains(monitoringProtocols, protocol) {
+					return fmt.Errorf("invalid --protocol %q: must be one of %s", protocol, strings.Join(monitoringProtocols, ", "))
+				}
+			}
+			if flags.Changed("port") && (port < 1 || port > 65535) {
+				return fmt.Errorf("invalid --port %d: must be between 1 and 65535", port)
+			}
+
+			client := api.NewClient()
+			pool, err := getClusterPool(client, domainID, clusterID)
+			if err != nil {
+				return err
+			}
+
+			if flags.Changed("protocol") {
+				pool.MonitoringProtocol = protocol
+			}
+			if flags.Changed("port") {
+				pool.MonitoringPort = types.FlexibleInt(port)
+			}
+			if flags.Changed("method") {
+				pool.MonitoringMethod = strings.ToUpper(method)
+			}
+			if flags.Changed("path") {
+				pool.MonitoringPath = path
+			}
+			if flags.Changed("error-reporting") {
+				pool.MonitoringErrorReporting = types.NumericBool(errorReporting)
+			}
+			if pool.MonitoringProtocol == "" {
+				return fmt.Errorf("--protocol is required: monitoring is not configured for cluster %d yet", clusterID)
+			}
+
+			body := map[string]interface{}{
+				"name":                       pool.Name,
+				"port":                       pool.Port,
+				"method":                     pool.Method,
+				"description":                pool.Description,
+				"error_reporting":            pool.ErrorReporting,
+				"monitoring_protocol":        pool.MonitoringProtocol,
+				"monitoring_port":            pool.MonitoringPort,
+				"monitoring_method":          pool.MonitoringMethod,
+				"monitoring_path":            pool.MonitoringPath,
+				"monitoring_error_reporting": pool.MonitoringErrorReporting,
+			}
+			if pool.HashKey != "" {
+				body["hash_key"] = pool.HashKey
+			}
+
+			_, err = client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d", domainID, clusterID), body)
+			if err != nil {
+				return err
+			}
+
+			output.Infof("Monitoring for cluster %d set to %s\n", clusterID, pool.monitoringSummary())
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
+	cmd.Flags().StringVar(&protocol, "protocol", "", "Health check protocol ("+strings.Join(monitoringProtocols, "/")+")")
+	cmd.Flags().IntVar(&port, "port", 0, "Health check port")
+	cmd.Flags().StringVar(&method, "method", "", "HTTP method for health checks (e.g. GET, HEAD)")
+	cmd.Flags().StringVar(&path, "path", "", "Path to request for HTTP(S) health checks, e.g. /health")
+	cmd.Flags().BoolVar(&errorReporting, "error-reporting", false, "Report health check failures")
+
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("cluster")
+
+	return cmd
+}
+
 func newClusterServerCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "server",
@@ -341,6 +552,7 @@ func newClusterServerCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newClusterServerAddCmd())
+	cmd.AddCommand(newClusterServerUpdateCmd())
 	cmd.AddCommand(newClusterServerDeleteCmd())
 
 	return cmd
@@ -367,17 +579,17 @@ func newClusterServerAddCmd() *cobra.Command {
 				body["host_header"] = hostHeader
 			}
 
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/%d/servers", domainID, clusterID), body)
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d/servers", domainID, clusterID), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Server %s added to cluster successfully\n", address)
+			output.Infof("Server %s added to cluster successfully\n", address)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
 	cmd.Flags().StringVar(&address, "address", "", "Server address (IP or hostname)")
 	cmd.Flags().IntVar(&port, "port", 443, "Server port")
@@ -393,6 +605,120 @@ func newClusterServerAddCmd() *cobra.Command {
 	return cmd
 }
 
+// getClusterPool finds a pool among a domain's clusters
+func getClusterPool(client *api.Client, domainID, clusterID int) (*ClusterPool, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/cluster", domainID))
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []ClusterPool
+	if err := json.Unmarshal(resp.Data, &pools); err != nil {
+		return nil, fmt.Errorf("failed to parse clusters: %w", err)
+	}
+
+	for _, pool := range pools {
+		if pool.ID == clusterID {
+			return &pool, nil
+		}
+	}
+	return nil, fmt.Errorf("cluster %d not found", clusterID)
+}
+
+// getClusterServer finds a backend server in a cluster pool
+func getClusterServer(client *api.Client, domainID, clusterID, serverID int) (*ClusterServer, error) {
+	pool, err := getClusterPool(client, domainID, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, server := range pool.Servers {
+		if server.ID == serverID {
+			return &server, nil
+		}
+	}
+	return nil, fmt.Errorf("server %d not found in cluster %d", serverID, clusterID)
+}
+
+func newClusterServerUpdateCmd() *cobra.Command {
+	var domainID, clusterID, serverID, port, weight, priority int
+	var address, hostHeader, protocol string
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a cluster server",
+		Long:  "Change settings of a backend server in place. Only the flags you pass are changed; the server stays in the pool throughout.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if !flags.Changed("address") && !flags.Changed("port") && !flags.Changed("weight") &&
+				!flags.Changed("priority") && !flags.Changed("protocol") && !flags.Changed("host-header") {
+				return fmt.Errorf("no fields to update")
+			}
+			if flags.Changed("weight") && (weight < 1 || weight > 100) {
+				return fmt.Errorf("invalid --weight %d: must be between 1 and 100", weight)
+			}
+
+			client := api.NewClient()
+			server, err := getClusterServer(client, domainID, clusterID, serverID)
+			if err != nil {
+				return err
+			}
+
+			if flags.Changed("address") {
+				server.Address = address
+			}
+			if flags.Changed("port") {
+				server.Port = types.FlexibleInt(port)
+			}
+			if flags.Changed("weight") {
+				server.Weight = weight
+			}
+			if flags.Changed("priority") {
+				server.Priority = priority
+			}
+			if flags.Changed("protocol") {
+				server.Protocol = protocol
+			}
+			if flags.Changed("host-header") {
+				server.HostHeader = hostHeader
+			}
+
+			body := map[string]interface{}{
+				"address":     server.Address,
+				"port":        server.Port,
+				"weight":      server.Weight,
+				"priority":    server.Priority,
+				"protocol":    server.Protocol,
+				"host_header": server.HostHeader,
+			}
+
+			_, err = client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d/servers/%d", domainID, clusterID, serverID), body)
+			if err != nil {
+				return err
+			}
+
+			output.Infof("Cluster server %d updated successfully\n", serverID)
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
+	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID")
+	cmd.Flags().StringVar(&address, "address", "", "Server address (IP or hostname)")
+	cmd.Flags().IntVar(&port, "port", 0, "Server port")
+	cmd.Flags().IntVar(&weight, "weight", 0, "Server weight (1-100)")
+	cmd.Flags().IntVar(&priority, "priority", 0, "Server priority")
+	cmd.Flags().StringVar(&protocol, "protocol", "", "Protocol (HTTP/HTTPS)")
+	cmd.Flags().StringVar(&hostHeader, "host-header", "", "Custom host header (empty to clear)")
+
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("cluster")
+	cmd.MarkFlagRequired("server")
+
+	return cmd
+}
+
 func newClusterServerDeleteCmd() *cobra.Command {
 	var domainID, clusterID, serverID int
 	var force bool
@@ -402,27 +728,27 @@ func newClusterServerDeleteCmd() *cobra.Command {
 		Short: "Remove a server from cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to remove server %d? (yes/no): ", serverID)
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to remove server %d?", serverID))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/%d/servers/%d", domainID, clusterID, serverID))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d/servers/%d", domainID, clusterID, serverID))
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Server removed from cluster successfully")
+			output.Infoln("Server removed from cluster successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
 	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
@@ -443,19 +769,19 @@ func newClusterAssignCmd() *cobra.Command {
 		Long:  "Assign a cluster pool to handle requests for a specific path/page rule.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/%d/assign", domainID, clusterID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d/assign", domainID, clusterID), map[string]interface{}{
 				"path_id": pathID,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Cluster %d assigned to path %d successfully\n", clusterID, pathID)
+			output.Infof("Cluster %d assigned to path %d successfully\n", clusterID, pathID)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
 	cmd.Flags().IntVar(&pathID, "path", 0, "Path ID to assign cluster to")
 
@@ -475,17 +801,17 @@ func newClusterUnassignCmd() *cobra.Command {
 		Long:  "Remove cluster assignment from a specific path/page rule.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/cluster/%d/assign/%d", domainID, clusterID, pathID))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/cluster/%d/assign/%d", domainID, clusterID, pathID))
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Cluster %d unassigned from path %d successfully\n", clusterID, pathID)
+			output.Infof("Cluster %d unassigned from path %d successfully\n", clusterID, pathID)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&clusterID, "cluster", 0, "Cluster ID")
 	cmd.Flags().IntVar(&pathID, "path", 0, "Path ID to unassign cluster from")
 
@@ -0,0 +1,56 @@
+package cdn
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+)
+
+// configChange is one API call of an import plan, such as ddos import or
+// waf import
+type configChange struct {
+	description string
+	endpoint    string
+	body        map[string]interface{}
+}
+
+// applyConfigChanges prints the plan for importing file, then sends each
+// change with send (client.Post or client.Put) unless --dry-run is set.
+// A failed change doesn't stop the others; the error counts the failures.
+// subject names what is being configured, e.g. "the WAF".
+func applyConfigChanges(changes []configChange, send func(endpoint string, body interface{}) (*api.Response, error), subject, file string) error {
+	if len(changes) == 0 {
+		output.Infof("No changes: %s already matches %s\n", subject, file)
+		return nil
+	}
+
+	output.Infof("%d change(s) to apply:\n", len(changes))
+	for _, c := range changes {
+		output.Infof("  - %s\n", c.description)
+	}
+	if config.GetConfig().DryRun {
+		return nil
+	}
+
+	output.Infoln()
+	failed := 0
+	progress := output.NewProgress(len(changes))
+	for _, c := range changes {
+		progress.Step(c.description)
+		_, err := send(c.endpoint, c.body)
+		progress.Clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed: %s: %v\n", c.description, err)
+			failed++
+		}
+	}
+
+	output.Infof("Applied %d of %d changes\n", len(changes)-failed, len(changes))
+	if failed > 0 {
+		return fmt.Errorf("%d changes could not be applied", failed)
+	}
+	return nil
+}
@@ -3,10 +3,15 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type CustomPages struct {
@@ -42,14 +47,13 @@ func newCustomPagesGetCmd() *cobra.Command {
 		Short: "Get custom error pages",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/custom-pages", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/custom-pages", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var pages CustomPages
@@ -70,7 +74,7 @@ func newCustomPagesGetCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -85,15 +89,21 @@ func printPageStatus(name, content string) {
 	fmt.Printf("%-30s %s\n", name+":", status)
 }
 
+// customPageWarnSize is the page size above which set warns; error pages
+// are served on every failed request, so they should stay small
+const customPageWarnSize = 256 * 1024
+
 func newCustomPagesSetCmd() *cobra.Command {
 	var domainID int
 	var errorCode int
-	var htmlContent string
+	var htmlContent, htmlFile string
+	var fromStdin bool
 
 	cmd := &cobra.Command{
 		Use:   "set",
 		Short: "Set custom error page",
-		Long: `Set custom HTML content for an error page.
+		Long: `Set custom HTML content for an error page, given inline (--html),
+as a path (--html-file) or on standard input (--stdin).
 Supported error codes: 403, 404, 500, 502, 503, 504`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			validCodes := map[int]bool{403: true, 404: true, 500: true, 502: true, 503: true, 504: true}
@@ -101,8 +111,30 @@ Supported error codes: 403, 404, 500, 502, 503, 504`,
 				return fmt.Errorf("invalid error code: %d (valid: 403, 404, 500, 502, 503, 504)", errorCode)
 			}
 
+			switch {
+			case htmlFile != "":
+				data, err := os.ReadFile(htmlFile)
+				if err != nil {
+					return fmt.Errorf("failed to read HTML file: %w", err)
+				}
+				htmlContent = string(data)
+			case fromStdin:
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return fmt.Errorf("failed to read HTML from stdin: %w", err)
+				}
+				htmlContent = string(data)
+			}
+
+			if strings.TrimSpace(htmlContent) == "" {
+				return fmt.Errorf("HTML content is empty")
+			}
+			if len(htmlContent) > customPageWarnSize {
+				fmt.Fprintf(os.Stderr, "Warning: the page is %s; error pages this large slow down every failed request\n", util.FormatBytes(int64(len(htmlContent)), util.IEC))
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/custom-pages", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/custom-pages", domainID), map[string]interface{}{
 				"error_code": errorCode,
 				"content":    htmlContent,
 			})
@@ -110,17 +142,20 @@ Supported error codes: 403, 404, 500, 502, 503, 504`,
 				return err
 			}
 
-			fmt.Printf("Custom page for error %d set successfully\n", errorCode)
+			output.Infof("Custom page for error %d set successfully\n", errorCode)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&errorCode, "code", 0, "Error code (403, 404, 500, 502, 503, 504)")
 	cmd.Flags().StringVar(&htmlContent, "html", "", "HTML content for the error page")
+	cmd.Flags().StringVar(&htmlFile, "html-file", "", "Path to an HTML file for the error page")
+	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "Read the HTML from standard input")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("code")
-	cmd.MarkFlagRequired("html")
+	cmd.MarkFlagsOneRequired("html", "html-file", "stdin")
+	cmd.MarkFlagsMutuallyExclusive("html", "html-file", "stdin")
 
 	return cmd
 }
@@ -139,17 +174,17 @@ func newCustomPagesDeleteCmd() *cobra.Command {
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/custom-pages", domainID))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/custom-pages", domainID))
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Custom page for error %d deleted (restored to default)\n", errorCode)
+			output.Infof("Custom page for error %d deleted (restored to default)\n", errorCode)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&errorCode, "code", 0, "Error code")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("code")
@@ -3,11 +3,14 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
 	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type DDoSSettings struct {
@@ -19,6 +22,9 @@ type DDoSSettings struct {
 	UnderAttack       types.NumericBool `json:"under_attack"`
 	JsChallenge       types.NumericBool `json:"js_challenge"`
 	CaptchaChallenge  types.NumericBool `json:"captcha_challenge"`
+	// Challenge counts, when the API reports them
+	ChallengesIssued  types.NullableInt `json:"challenges_issued"`
+	ChallengesPassed  types.NullableInt `json:"challenges_passed"`
 }
 
 func NewDDoSCmd() *cobra.Command {
@@ -30,54 +36,69 @@ func NewDDoSCmd() *cobra.Command {
 
 	cmd.AddCommand(newDDoSStatusCmd())
 	cmd.AddCommand(newDDoSModeCmd())
+	cmd.AddCommand(newDDoSUnderAttackCmd())
 	cmd.AddCommand(newDDoSCaptchaCmd())
 	cmd.AddCommand(newDDoSTTLCmd())
+	cmd.AddCommand(newDDoSExportCmd())
+	cmd.AddCommand(newDDoSImportCmd())
 
 	return cmd
 }
 
 func newDDoSStatusCmd() *cobra.Command {
 	var domainID int
-	var jsonOutput bool
+	var jsonOutput, watch bool
+	var interval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Get DDoS protection status",
+		Long:  "Show DDoS protection settings. With --watch, keep polling and print a line with the mode, Under Attack state and challenge counts at each poll until Ctrl-C.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch && jsonOutput {
+				return fmt.Errorf("--watch cannot be combined with --json")
+			}
+
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/ddos", domainID))
+			settings, err := getDDoSSettings(client, domainID)
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
-			}
-
-			var settings DDoSSettings
-			if err := json.Unmarshal(resp.Data, &settings); err != nil {
-				return fmt.Errorf("failed to parse settings: %w", err)
+				return output.PrintJSONValue(settings)
 			}
 
 			fmt.Printf("DDoS Protection Settings\n")
 			fmt.Printf("========================\n")
 			fmt.Printf("Mode:              %s\n", settings.Mode)
-			fmt.Printf("Under Attack:      %v\n", settings.UnderAttack.Bool())
-			fmt.Printf("JS Challenge:      %v\n", settings.JsChallenge.Bool())
-			fmt.Printf("Captcha Challenge: %v\n", settings.CaptchaChallenge.Bool())
+			fmt.Printf("Under Attack:      %s\n", settings.UnderAttack)
+			fmt.Printf("JS Challenge:      %s\n", settings.JsChallenge)
+			fmt.Printf("Captcha Challenge: %s\n", settings.CaptchaChallenge)
 			fmt.Printf("Captcha Module:    %s\n", settings.CaptchaModule)
 			fmt.Printf("\nTTL Settings:\n")
 			fmt.Printf("  Cookie TTL:      %d seconds\n", settings.CookieTTL)
 			fmt.Printf("  JS TTL:          %d seconds\n", settings.JsTTL)
 			fmt.Printf("  Captcha TTL:     %d seconds\n", settings.CaptchaTTL)
+			if settings.ChallengesIssued.Valid {
+				fmt.Printf("\nChallenges:\n")
+				fmt.Printf("  Issued:          %d\n", settings.ChallengesIssued.Value)
+				if settings.ChallengesPassed.Valid {
+					fmt.Printf("  Passed:          %d\n", settings.ChallengesPassed.Value)
+				}
+			}
 
-			return nil
+			if !watch {
+				return nil
+			}
+			return watchDDoS(client, domainID, interval)
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep polling and print the protection state at each poll")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between polls in --watch mode")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -97,7 +118,7 @@ func newDDoSModeCmd() *cobra.Command {
   - under_attack: Maximum protection (use when under attack)`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ddos", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ddos", domainID), map[string]interface{}{
 				"mode": mode,
 			})
 			if err != nil {
@@ -119,7 +140,7 @@ func newDDoSModeCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&mode, "mode", "normal", "Protection mode (off/normal/high/under_attack)")
 
 	cmd.MarkFlagRequired("domain")
@@ -128,6 +149,79 @@ func newDDoSModeCmd() *cobra.Command {
 	return cmd
 }
 
+// watchDDoS polls the protection settings and prints one line per poll
+func watchDDoS(client *api.Client, domainID int, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid --interval %s: must be positive", interval)
+	}
+
+	fmt.Printf("\nWatching DDoS protection for domain %d every %s (Ctrl-C to stop)...\n", domainID, interval)
+	fmt.Printf("%-8s  %-12s  %-12s  %-10s  %s\n", "TIME", "MODE", "UNDER ATTACK", "CHALLENGES", "PASSED")
+	return util.Poll(client.Context(), 0, interval, func() (bool, error) {
+		resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/ddos", domainID))
+		if err != nil {
+			return false, err
+		}
+		var settings DDoSSettings
+		if err := json.Unmarshal(resp.Data, &settings); err != nil {
+			return false, fmt.Errorf("failed to parse settings: %w", err)
+		}
+
+		issued, passed := "-", "-"
+		if settings.ChallengesIssued.Valid {
+			issued = fmt.Sprint(settings.ChallengesIssued.Value)
+		}
+		if settings.ChallengesPassed.Valid {
+			passed = fmt.Sprint(settings.ChallengesPassed.Value)
+		}
+		fmt.Printf("%-8s  %-12s  %-12s  %-10s  %s\n", time.Now().Format("15:04:05"), settings.Mode, settings.UnderAttack, issued, passed)
+		return false, nil
+	})
+}
+
+func newDDoSUnderAttackCmd() *cobra.Command {
+	var domainID int
+	var on, off bool
+
+	cmd := &cobra.Command{
+		Use:   "under-attack",
+		Short: "Switch Under Attack mode on or off",
+		Long:  "Shortcut for ddos mode: --on sets the under_attack mode (maximum protection), --off goes back to normal.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mode := "normal"
+			if on {
+				mode = "under_attack"
+			}
+
+			client := api.NewClient()
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ddos", domainID), map[string]interface{}{
+				"mode": mode,
+			})
+			if err != nil {
+				return err
+			}
+
+			if on {
+				fmt.Println("Under Attack mode ON: maximum protection, visitors are challenged")
+				fmt.Printf("Watch the effect with: mizban ddos status --domain %d --watch\n", domainID)
+			} else {
+				fmt.Println("Under Attack mode OFF: protection mode set to normal")
+			}
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&on, "on", false, "Turn Under Attack mode on")
+	cmd.Flags().BoolVar(&off, "off", false, "Turn Under Attack mode off (back to normal)")
+
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagsOneRequired("on", "off")
+	cmd.MarkFlagsMutuallyExclusive("on", "off")
+
+	return cmd
+}
+
 func newDDoSCaptchaCmd() *cobra.Command {
 	var domainID int
 	var module string
@@ -141,7 +235,7 @@ func newDDoSCaptchaCmd() *cobra.Command {
   - turnstile:  Cloudflare Turnstile`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ddos/captcha-module", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ddos/captcha-module", domainID), map[string]interface{}{
 				"module": module,
 			})
 			if err != nil {
@@ -153,7 +247,7 @@ func newDDoSCaptchaCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&module, "module", "recaptcha", "Captcha module (recaptcha/hcaptcha/turnstile)")
 
 	cmd.MarkFlagRequired("domain")
@@ -182,8 +276,12 @@ func newDDoSCookieTTLCmd() *cobra.Command {
 		Use:   "cookie",
 		Short: "Set cookie challenge TTL",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateDDoSTTL("--ttl", ttl); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ddos/set-ttl/cookie", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ddos/set-ttl/cookie", domainID), map[string]interface{}{
 				"ttl": ttl,
 			})
 			if err != nil {
@@ -195,7 +293,7 @@ func newDDoSCookieTTLCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&ttl, "ttl", 3600, "TTL in seconds")
 
 	cmd.MarkFlagRequired("domain")
@@ -211,8 +309,12 @@ func newDDoSJsTTLCmd() *cobra.Command {
 		Use:   "js",
 		Short: "Set JavaScript challenge TTL",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateDDoSTTL("--ttl", ttl); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ddos/set-ttl/js", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ddos/set-ttl/js", domainID), map[string]interface{}{
 				"ttl": ttl,
 			})
 			if err != nil {
@@ -224,7 +326,7 @@ func newDDoSJsTTLCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&ttl, "ttl", 3600, "TTL in seconds")
 
 	cmd.MarkFlagRequired("domain")
@@ -240,8 +342,12 @@ func newDDoSCaptchaTTLCmd() *cobra.Command {
 		Use:   "captcha",
 		Short: "Set captcha challenge TTL",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateDDoSTTL("--ttl", ttl); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ddos/set-ttl/captcha", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ddos/set-ttl/captcha", domainID), map[string]interface{}{
 				"ttl": ttl,
 			})
 			if err != nil {
@@ -253,7 +359,7 @@ func newDDoSCaptchaTTLCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&ttl, "ttl", 3600, "TTL in seconds")
 
 	cmd.MarkFlagRequired("domain")
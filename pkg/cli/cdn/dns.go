@@ -1,25 +1,164 @@
 package cdn
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/types"
 )
 
 type DNSRecord struct {
-	ID       int    `json:"id"`
-	Type     string `json:"type"`
-	Name     string `json:"name"`
-	Content  string `json:"content"`
-	TTL      int    `json:"ttl"`
-	Priority int    `json:"priority,omitempty"`
-	Port     int    `json:"port,omitempty"`
-	Protocol string `json:"protocol,omitempty"`
-	Proxy    string `json:"proxy"`
+	ID       int               `json:"id"`
+	Type     string            `json:"type"`
+	Name     string            `json:"name"`
+	Content  string            `json:"content"`
+	TTL      int               `json:"ttl"`
+	Priority int               `json:"priority,omitempty"`
+	Port     types.FlexibleInt `json:"port,omitempty"`
+	Protocol string            `json:"protocol,omitempty"`
+	Proxy    string            `json:"proxy"`
+}
+
+// Proxied reports whether traffic for the record goes through the CDN
+func (r DNSRecord) Proxied() bool {
+	return r.Proxy == "ACTIVE"
+}
+
+// updateBody builds a full PUT body from the record's current values.
+//
+// Partial updates follow a read-modify-write pattern: fetch the record with
+// getDNSRecord, change only the fields the user asked for, and PUT the
+// result of updateBody so nothing else is reset to a default.
+func (r DNSRecord) updateBody() map[string]interface{} {
+	body := r.createBody()
+	body["record_id"] = r.ID
+	return body
+}
+
+// createBody builds a POST body for creating the record
+func (r DNSRecord) createBody() map[string]interface{} {
+	protocol := r.Protocol
+	if protocol == "" {
+		protocol = "DEFAULT"
+	}
+
+	body := map[string]interface{}{
+		"type":        r.Type,
+		"name":        r.Name,
+		"destination": r.Content,
+		"ttl":         r.TTL,
+		"protocol":    protocol,
+		"proxy":       r.Proxied(),
+	}
+	if r.Priority > 0 {
+		body["priority"] = r.Priority
+	}
+	if r.Port > 0 {
+		body["port"] = r.Port
+	}
+	return body
+}
+
+// getDNSRecord fetches a single record of a domain
+func getDNSRecord(client *api.Client, domainID int, recordID string) (*DNSRecord, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%s", domainID, recordID))
+	if err != nil {
+		return nil, err
+	}
+
+	var record DNSRecord
+	if err := json.Unmarshal(resp.Data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse record: %w", err)
+	}
+	return &record, nil
+}
+
+// getDNSRecords lists every record of a domain
+func getDNSRecords(client *api.Client, domainID int) ([]DNSRecord, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns", domainID))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	if err := json.Unmarshal(resp.Data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse records: %w", err)
+	}
+	return records, nil
+}
+
+// matchDNSRecords returns the records with the given name and type. Names
+// compare case-insensitively and ignore a trailing dot.
+func matchDNSRecords(records []DNSRecord, name, recordType string) []DNSRecord {
+	want := strings.TrimSuffix(name, ".")
+	var matches []DNSRecord
+	for _, r := range records {
+		if strings.EqualFold(r.Type, recordType) && strings.EqualFold(strings.TrimSuffix(r.Name, "."), want) {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+// findDNSRecord resolves a name and type to the single matching record
+func findDNSRecord(client *api.Client, domainID int, name, recordType string) (*DNSRecord, error) {
+	records, err := getDNSRecords(client, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchDNSRecords(records, name, recordType)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s record named %q on domain %d", strings.ToUpper(recordType), name, domainID)
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, ambiguousDNSRecordError(matches, name, recordType)
+}
+
+// ambiguousDNSRecordError lists the candidates when a name and type match
+// more than one record
+func ambiguousDNSRecordError(matches []DNSRecord, name, recordType string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s records are named %q; use a record ID to pick one:", len(matches), strings.ToUpper(recordType), name)
+	for _, r := range matches {
+		fmt.Fprintf(&b, "\n  %d  %s", r.ID, r.Content)
+	}
+	return errors.New(b.String())
+}
+
+// dnsRecordSelector returns the record ID named by a positional argument or
+// by --name and --type
+func dnsRecordSelector(client *api.Client, domainID int, args []string, name, recordType string) (string, error) {
+	if len(args) == 1 {
+		if name != "" || recordType != "" {
+			return "", fmt.Errorf("pass either a record ID or --name and --type, not both")
+		}
+		return args[0], nil
+	}
+	if name == "" || recordType == "" {
+		return "", fmt.Errorf("pass a record ID, or --name and --type to select the record")
+	}
+
+	record, err := findDNSRecord(client, domainID, name, recordType)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(record.ID), nil
 }
 
 func NewDNSCmd() *cobra.Command {
@@ -33,8 +172,11 @@ func NewDNSCmd() *cobra.Command {
 	cmd.AddCommand(newDNSGetCmd())
 	cmd.AddCommand(newDNSAddCmd())
 	cmd.AddCommand(newDNSUpdateCmd())
+	cmd.AddCommand(newDNSUpsertCmd())
+	cmd.AddCommand(newDNSApplyCmd())
 	cmd.AddCommand(newDNSDeleteCmd())
 	cmd.AddCommand(newDNSProxiableCmd())
+	cmd.AddCommand(newDNSToggleProxyCmd())
 	cmd.AddCommand(newDNSImportCmd())
 	cmd.AddCommand(newDNSExportCmd())
 	cmd.AddCommand(newDNSFetchRecordsCmd())
@@ -47,40 +189,40 @@ func NewDNSCmd() *cobra.Command {
 func newDNSListCmd() *cobra.Command {
 	var domainID int
 	var jsonOutput bool
+	var recordTypes []string
+	var nameContains string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List DNS records",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns", domainID))
+			records, err := getDNSRecords(client, domainID)
 			if err != nil {
 				return err
 			}
 
-			var records []DNSRecord
-			if err := json.Unmarshal(resp.Data, &records); err != nil {
-				return fmt.Errorf("failed to parse records: %w", err)
+			filtered := len(recordTypes) > 0 || nameContains != ""
+			if filtered {
+				records = filterDNSRecords(records, recordTypes, nameContains)
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(records, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(records)
 			}
 
 			if len(records) == 0 {
-				fmt.Println("No DNS records found")
+				if filtered {
+					fmt.Println("No DNS records match the given filters")
+				} else {
+					fmt.Println("No DNS records found")
+				}
 				return nil
 			}
 
-			fmt.Printf("%-6s %-8s %-25s %-40s %-8s %-10s %-8s\n", "ID", "TYPE", "NAME", "CONTENT", "TTL", "PROTOCOL", "PROXIED")
-			fmt.Println(strings.Repeat("-", 115))
+			table := output.NewTable("ID", "TYPE", "NAME", "CONTENT", "TTL", "PROTOCOL", "PROXIED")
 			for _, r := range records {
-				proxied := "No"
-				if r.Proxy == "ACTIVE" {
-					proxied = "Yes"
-				}
+				proxied := types.FormatBool(r.Proxied())
 				// Show protocol with port if not default
 				protocol := r.Protocol
 				if protocol == "" || protocol == "DEFAULT" {
@@ -89,39 +231,74 @@ func newDNSListCmd() *cobra.Command {
 				if r.Port > 0 {
 					protocol = fmt.Sprintf("%s:%d", protocol, r.Port)
 				}
-				fmt.Printf("%-6d %-8s %-25s %-40s %-8d %-10s %-8s\n",
-					r.ID, r.Type, truncate(r.Name, 25), truncate(r.Content, 40), r.TTL, protocol, proxied)
+				table.AddRow(r.ID, r.Type, r.Name, r.Content, r.TTL, protocol, proxied)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().StringSliceVar(&recordTypes, "type", nil, "Only show these record types (e.g. A,CNAME)")
+	cmd.Flags().StringVar(&nameContains, "name-contains", "", "Only show records whose name contains this text")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
+// filterDNSRecords keeps records matching all given filters: one of
+// recordTypes (case-insensitive) and a name containing nameContains
+func filterDNSRecords(records []DNSRecord, recordTypes []string, nameContains string) []DNSRecord {
+	nameContains = strings.ToLower(nameContains)
+
+	result := make([]DNSRecord, 0, len(records))
+	for _, r := range records {
+		if len(recordTypes) > 0 && !containsFold(recordTypes, r.Type) {
+			continue
+		}
+		if nameContains != "" && !strings.Contains(strings.ToLower(r.Name), nameContains) {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
 func newDNSGetCmd() *cobra.Command {
 	var domainID int
 	var jsonOutput bool
+	var name, recordType string
 
 	cmd := &cobra.Command{
 		Use:   "get [record-id]",
 		Short: "Get a single DNS record",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Get a DNS record by ID, or by name and type (e.g. --name www --type A).",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/%s", domainID, args[0]))
+			recordID, err := dnsRecordSelector(client, domainID, args, name, recordType)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%s", domainID, recordID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var record DNSRecord
@@ -151,9 +328,12 @@ func newDNSGetCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().StringVar(&name, "name", "", "Select the record by name (with --type)")
+	cmd.Flags().StringVar(&recordType, "type", "", "Select the record by type (with --name)")
 	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagsRequiredTogether("name", "type")
 
 	return cmd
 }
@@ -168,14 +348,13 @@ func newDNSProxiableCmd() *cobra.Command {
 		Long:  "List DNS records that can be proxied through CDN (includes trashed records).",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/proxiable", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns/proxiable", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var records []DNSRecord
@@ -188,22 +367,18 @@ func newDNSProxiableCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-8s %-25s %-40s %-8s\n", "ID", "TYPE", "NAME", "CONTENT", "PROXIED")
-			fmt.Println(strings.Repeat("-", 95))
+			table := output.NewTable("ID", "TYPE", "NAME", "CONTENT", "PROXIED")
 			for _, r := range records {
-				proxied := "No"
-				if r.Proxy == "ACTIVE" {
-					proxied = "Yes"
-				}
-				fmt.Printf("%-6d %-8s %-25s %-40s %-8s\n",
-					r.ID, r.Type, truncate(r.Name, 25), truncate(r.Content, 40), proxied)
+				proxied := types.FormatBool(r.Proxied())
+				table.AddRow(r.ID, r.Type, r.Name, r.Content, proxied)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -212,14 +387,18 @@ func newDNSProxiableCmd() *cobra.Command {
 
 func newDNSAddCmd() *cobra.Command {
 	var domainID, ttl, priority, port int
-	var recordType, name, destination, protocol string
+	var recordType, name, destination, protocol, fromFile string
 	var proxy bool
 
 	cmd := &cobra.Command{
 		Use:   "add",
 		Short: "Add a DNS record",
+		Long:  "Add a DNS record from flags, or many records from a JSON or CSV file with --from-file.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
+			if fromFile != "" {
+				return addDNSRecordsFromFile(client, domainID, fromFile)
+			}
 
 			body := map[string]interface{}{
 				"type":        recordType,
@@ -236,7 +415,7 @@ func newDNSAddCmd() *cobra.Command {
 				body["port"] = port
 			}
 
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns", domainID), body)
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns", domainID), body)
 			if err != nil {
 				return err
 			}
@@ -246,17 +425,18 @@ func newDNSAddCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse record: %w", err)
 			}
 
-			fmt.Printf("DNS record added successfully!\n")
-			fmt.Printf("ID: %d\n", record.ID)
-			fmt.Printf("Type: %s\n", record.Type)
-			fmt.Printf("Name: %s\n", record.Name)
-			fmt.Printf("Content: %s\n", record.Content)
+			output.Infof("DNS record added successfully!\n")
+			output.Infof("ID: %d\n", record.ID)
+			output.PrintID(record.ID)
+			output.Infof("Type: %s\n", record.Type)
+			output.Infof("Name: %s\n", record.Name)
+			output.Infof("Content: %s\n", record.Content)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&recordType, "type", "", "Record type (A, AAAA, CNAME, MX, TXT, etc.)")
 	cmd.Flags().StringVar(&name, "name", "", "Record name (@ for root)")
 	cmd.Flags().StringVar(&destination, "destination", "", "Record destination/value")
@@ -265,15 +445,180 @@ func newDNSAddCmd() *cobra.Command {
 	cmd.Flags().IntVar(&port, "port", 0, "Port (for proxied records with custom port)")
 	cmd.Flags().StringVar(&protocol, "protocol", "DEFAULT", "Protocol (DEFAULT/HTTPS/HTTP)")
 	cmd.Flags().BoolVar(&proxy, "proxy", false, "Enable CDN proxy")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Add the records in a JSON or CSV file (.csv) instead")
 
 	cmd.MarkFlagRequired("domain")
-	cmd.MarkFlagRequired("type")
-	cmd.MarkFlagRequired("name")
-	cmd.MarkFlagRequired("destination")
+	cmd.MarkFlagsOneRequired("from-file", "type")
+	cmd.MarkFlagsRequiredTogether("type", "name", "destination")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "type")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "name")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "destination")
 
 	return cmd
 }
 
+// addDNSRecordsFromFile creates every record in a batch file, continuing past
+// failures and reporting a summary. With --dry-run it only validates the file.
+func addDNSRecordsFromFile(client *api.Client, domainID int, path string) error {
+	records, labels, err := readDNSRecordsFile(path)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("no records found in %s", path)
+	}
+
+	invalid := 0
+	for i, r := range records {
+		if err := validateDNSRecordInput(r); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", labels[i], err)
+			invalid++
+		}
+	}
+
+	if config.GetConfig().DryRun {
+		table := output.NewTable("TYPE", "NAME", "CONTENT", "TTL", "PROXY")
+		for _, r := range records {
+			table.AddRow(r.Type, r.Name, r.Content, r.TTL, types.FormatBool(r.Proxied()))
+		}
+		table.Render()
+		if invalid > 0 {
+			return fmt.Errorf("%d of %d records are invalid", invalid, len(records))
+		}
+		output.Infof("\n[dry-run] %d records would be created\n", len(records))
+		return nil
+	}
+
+	created, failed := 0, invalid
+	for i, r := range records {
+		if validateDNSRecordInput(r) != nil {
+			continue
+		}
+		if _, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns", domainID), r.createBody()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s (%s %s): %v\n", labels[i], r.Type, r.Name, err)
+			failed++
+			continue
+		}
+		created++
+	}
+
+	output.Infof("Created %d of %d records", created, len(records))
+	if failed > 0 {
+		output.Infof(" (%d failed)\n", failed)
+		return fmt.Errorf("%d records could not be created", failed)
+	}
+	output.Infoln()
+	return nil
+}
+
+// readDNSRecordsFile loads records from a JSON array or a CSV file with a
+// header row (type,name,content,ttl,priority[,port,protocol,proxy]), as
+// written by dns export. labels locate each record for error messages.
+func readDNSRecordsFile(path string) (records []DNSRecord, labels []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read records file: %w", err)
+	}
+
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		var rows []zoneRecord
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s as a JSON array of records: %w", path, err)
+		}
+		for i, z := range rows {
+			records = append(records, z.record())
+			labels = append(labels, fmt.Sprintf("record %d", i+1))
+		}
+		return records, labels, nil
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"type", "name", "content"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("CSV header is missing the %q column", required)
+		}
+	}
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	number := func(row []string, name string) (int, error) {
+		v := field(row, name)
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q", name, v)
+		}
+		return n, nil
+	}
+
+	for i, row := range rows[1:] {
+		label := fmt.Sprintf("line %d", i+2)
+		z := zoneRecord{
+			Type:     field(row, "type"),
+			Name:     field(row, "name"),
+			Content:  field(row, "content"),
+			Protocol: field(row, "protocol"),
+		}
+		if z.TTL, err = number(row, "ttl"); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", label, err)
+		}
+		if z.Priority, err = number(row, "priority"); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", label, err)
+		}
+		port, err := number(row, "port")
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", label, err)
+		}
+		z.Port = types.FlexibleInt(port)
+		proxy, err := parseProxySetting(field(row, "proxy"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", label, err)
+		}
+		z.Proxy = proxySetting(proxy)
+		r := z.record()
+		records = append(records, r)
+		labels = append(labels, label)
+	}
+	return records, labels, nil
+}
+
+// validateDNSRecordInput checks the fields every new record needs
+func validateDNSRecordInput(r DNSRecord) error {
+	var missing []string
+	if r.Type == "" {
+		missing = append(missing, "type")
+	}
+	if r.Name == "" {
+		missing = append(missing, "name")
+	}
+	if r.Content == "" {
+		missing = append(missing, "content")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	if r.TTL < 0 {
+		return fmt.Errorf("invalid ttl %d", r.TTL)
+	}
+	return nil
+}
+
 func newDNSUpdateCmd() *cobra.Command {
 	var domainID, recordID, ttl, priority, port int
 	var recordType, name, destination, protocol string
@@ -282,40 +627,162 @@ func newDNSUpdateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update a DNS record",
+		Long:  "Update a DNS record. Only the flags you pass are changed; every other field keeps its current value.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-
-			body := map[string]interface{}{
-				"record_id":   recordID,
-				"type":        recordType,
-				"name":        name,
-				"destination": destination,
-				"ttl":         ttl,
-				"protocol":    protocol,
-				"proxy":       proxy,
-			}
-			if priority > 0 {
-				body["priority"] = priority
+			record, err := getDNSRecord(client, domainID, strconv.Itoa(recordID))
+			if err != nil {
+				return err
 			}
-			if port > 0 {
-				body["port"] = port
+
+			update := DNSRecord{
+				Type:     recordType,
+				Name:     name,
+				Content:  destination,
+				TTL:      ttl,
+				Priority: priority,
+				Port:     types.FlexibleInt(port),
+				Protocol: protocol,
+				Proxy:    proxyState(proxy),
 			}
+			merged := mergeDNSRecordUpdate(*record, update, cmd.Flags().Changed)
 
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/%d", domainID, recordID), body)
+			body := merged.updateBody()
+			body["record_id"] = recordID
+
+			_, err = client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%d", domainID, recordID), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("DNS record updated successfully")
+			output.Infoln("DNS record updated successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&recordID, "record", 0, "Record ID")
 	cmd.Flags().StringVar(&recordType, "type", "", "Record type")
 	cmd.Flags().StringVar(&name, "name", "", "Record name")
 	cmd.Flags().StringVar(&destination, "destination", "", "Record destination/value")
+	cmd.Flags().IntVar(&ttl, "ttl", 0, "TTL in seconds")
+	cmd.Flags().IntVar(&priority, "priority", 0, "Priority (for MX records)")
+	cmd.Flags().IntVar(&port, "port", 0, "Port (for proxied records with custom port)")
+	cmd.Flags().StringVar(&protocol, "protocol", "", "Protocol (DEFAULT/HTTPS/HTTP)")
+	cmd.Flags().BoolVar(&proxy, "proxy", false, "Enable CDN proxy")
+
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("record")
+
+	return cmd
+}
+
+// mergeDNSRecordUpdate copies the fields of update whose flags changed
+// reports as set onto current, leaving every other field as fetched
+func mergeDNSRecordUpdate(current, update DNSRecord, changed func(flag string) bool) DNSRecord {
+	merged := current
+	if changed("type") {
+		merged.Type = update.Type
+	}
+	if changed("name") {
+		merged.Name = update.Name
+	}
+	if changed("destination") {
+		merged.Content = update.Content
+	}
+	if changed("ttl") {
+		merged.TTL = update.TTL
+	}
+	if changed("priority") {
+		merged.Priority = update.Priority
+	}
+	if changed("port") {
+		merged.Port = update.Port
+	}
+	if changed("protocol") {
+		merged.Protocol = update.Protocol
+	}
+	if changed("proxy") {
+		merged.Proxy = update.Proxy
+	}
+	return merged
+}
+
+func newDNSUpsertCmd() *cobra.Command {
+	var domainID, ttl, priority, port int
+	var recordType, name, destination, protocol string
+	var proxy bool
+
+	cmd := &cobra.Command{
+		Use:   "upsert",
+		Short: "Create or update a DNS record by name and type",
+		Long: `Create a DNS record, or update it in place if a record with the same name
+and type already exists. When updating, flags you don't pass keep their
+current values. Fails if several records share the name and type.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			records, err := getDNSRecords(client, domainID)
+			if err != nil {
+				return err
+			}
+
+			matches := matchDNSRecords(records, name, recordType)
+			if len(matches) > 1 {
+				return ambiguousDNSRecordError(matches, name, recordType)
+			}
+
+			flags := cmd.Flags()
+			desired := DNSRecord{Type: strings.ToUpper(recordType), Name: name, TTL: ttl, Protocol: protocol}
+			if len(matches) == 1 {
+				desired = matches[0]
+				if flags.Changed("ttl") {
+					desired.TTL = ttl
+				}
+				if flags.Changed("protocol") {
+					desired.Protocol = protocol
+				}
+			}
+			desired.Content = destination
+			if len(matches) == 0 || flags.Changed("priority") {
+				desired.Priority = priority
+			}
+			if len(matches) == 0 || flags.Changed("port") {
+				desired.Port = types.FlexibleInt(port)
+			}
+			if len(matches) == 0 || flags.Changed("proxy") {
+				desired.Proxy = proxyState(proxy)
+			}
+			if err := validateDNSRecordInput(desired); err != nil {
+				return err
+			}
+
+			if len(matches) == 0 {
+				record, err := createDNSRecord(client, domainID, desired)
+				if err != nil {
+					return err
+				}
+				output.Infof("Created %s record %s (ID: %d)\n", record.Type, record.Name, record.ID)
+				output.PrintID(record.ID)
+				return nil
+			}
+
+			changes := dnsRecordDiff(matches[0], desired)
+			if len(changes) == 0 {
+				output.Infof("%s record %s (ID: %d) is already up to date\n", desired.Type, desired.Name, desired.ID)
+				return nil
+			}
+			if err := updateDNSRecord(client, domainID, desired); err != nil {
+				return err
+			}
+			output.Infof("Updated %s record %s (ID: %d): %s\n", desired.Type, desired.Name, desired.ID, strings.Join(changes, ", "))
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&recordType, "type", "", "Record type (A, AAAA, CNAME, MX, TXT, etc.)")
+	cmd.Flags().StringVar(&name, "name", "", "Record name (@ for root)")
+	cmd.Flags().StringVar(&destination, "destination", "", "Record destination/value")
 	cmd.Flags().IntVar(&ttl, "ttl", 3600, "TTL in seconds")
 	cmd.Flags().IntVar(&priority, "priority", 0, "Priority (for MX records)")
 	cmd.Flags().IntVar(&port, "port", 0, "Port (for proxied records with custom port)")
@@ -323,31 +790,148 @@ func newDNSUpdateCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&proxy, "proxy", false, "Enable CDN proxy")
 
 	cmd.MarkFlagRequired("domain")
-	cmd.MarkFlagRequired("record")
+	cmd.MarkFlagRequired("type")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("destination")
 
 	return cmd
 }
 
+func createDNSRecord(client *api.Client, domainID int, r DNSRecord) (*DNSRecord, error) {
+	resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns", domainID), r.createBody())
+	if err != nil {
+		return nil, err
+	}
+
+	var record DNSRecord
+	if err := json.Unmarshal(resp.Data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse record: %w", err)
+	}
+	return &record, nil
+}
+
+func updateDNSRecord(client *api.Client, domainID int, r DNSRecord) error {
+	_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%d", domainID, r.ID), r.updateBody())
+	return err
+}
+
+func proxyState(proxied bool) string {
+	if proxied {
+		return "ACTIVE"
+	}
+	return "INACTIVE"
+}
+
+// dnsRecordDiff describes how desired differs from current, one entry per
+// changed field, e.g. "ttl 300 -> 3600"
+func dnsRecordDiff(current, desired DNSRecord) []string {
+	var changes []string
+	if current.Content != desired.Content {
+		changes = append(changes, fmt.Sprintf("content %s -> %s", current.Content, desired.Content))
+	}
+	if current.TTL != desired.TTL {
+		changes = append(changes, fmt.Sprintf("ttl %d -> %d", current.TTL, desired.TTL))
+	}
+	if current.Priority != desired.Priority {
+		changes = append(changes, fmt.Sprintf("priority %d -> %d", current.Priority, desired.Priority))
+	}
+	if current.Port != desired.Port {
+		changes = append(changes, fmt.Sprintf("port %d -> %d", current.Port, desired.Port))
+	}
+	if normalizeProtocol(current.Protocol) != normalizeProtocol(desired.Protocol) {
+		changes = append(changes, fmt.Sprintf("protocol %s -> %s", normalizeProtocol(current.Protocol), normalizeProtocol(desired.Protocol)))
+	}
+	if current.Proxied() != desired.Proxied() {
+		changes = append(changes, fmt.Sprintf("proxy %s -> %s", types.FormatBool(current.Proxied()), types.FormatBool(desired.Proxied())))
+	}
+	return changes
+}
+
+func normalizeProtocol(protocol string) string {
+	if protocol == "" {
+		return "DEFAULT"
+	}
+	return strings.ToUpper(protocol)
+}
+
 func newDNSDeleteCmd() *cobra.Command {
 	var domainID int
+	var name, recordType string
 
 	cmd := &cobra.Command{
 		Use:   "delete [record-id]",
 		Short: "Delete a DNS record",
+		Long:  "Delete a DNS record by ID, or by name and type (e.g. --name www --type A).",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			recordID, err := dnsRecordSelector(client, domainID, args, name, recordType)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%s", domainID, recordID))
+			if api.IsNotFound(err) {
+				output.Infoln("DNS record already gone")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			output.Infoln("DNS record deleted successfully")
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&name, "name", "", "Select the record by name (with --type)")
+	cmd.Flags().StringVar(&recordType, "type", "", "Select the record by type (with --name)")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagsRequiredTogether("name", "type")
+
+	return cmd
+}
+
+func newDNSToggleProxyCmd() *cobra.Command {
+	var domainID int
+	var proxy bool
+
+	cmd := &cobra.Command{
+		Use:   "toggle-proxy [record-id]",
+		Short: "Turn CDN proxying on or off for a record",
+		Long:  "Turn CDN proxying on or off for a record, keeping all other fields unchanged. Without --proxy the current setting is flipped.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/%s", domainID, args[0]))
+			record, err := getDNSRecord(client, domainID, args[0])
+			if err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Changed("proxy") {
+				proxy = !record.Proxied()
+			}
+
+			body := record.updateBody()
+			body["proxy"] = proxy
+
+			_, err = client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%s", domainID, args[0]), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("DNS record deleted successfully")
+			if proxy {
+				output.Infof("CDN proxy enabled for %s record %s\n", record.Type, record.Name)
+			} else {
+				output.Infof("CDN proxy disabled for %s record %s\n", record.Type, record.Name)
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&proxy, "proxy", false, "Enable (true) or disable (false) the CDN proxy")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -355,65 +939,211 @@ func newDNSDeleteCmd() *cobra.Command {
 
 func newDNSImportCmd() *cobra.Command {
 	var domainID int
-	var zone string
+	var zone, file string
+	var fromStdin bool
 
 	cmd := &cobra.Command{
 		Use:   "import",
 		Short: "Import DNS zone file",
+		Long:  "Import records from a BIND zone file given inline (--zone), as a path (--file) or on standard input (--stdin).",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case file != "":
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read zone file: %w", err)
+				}
+				zone = string(data)
+			case fromStdin:
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return fmt.Errorf("failed to read zone from stdin: %w", err)
+				}
+				zone = string(data)
+			}
+
+			zone = normalizeZone(zone)
+			if zone == "" {
+				return fmt.Errorf("zone file is empty")
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/import", domainID), map[string]interface{}{
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns/import", domainID), map[string]interface{}{
 				"zone": zone,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("DNS zone imported successfully")
+			type importResult struct {
+				Parsed  int         `json:"parsed"`
+				Created int         `json:"created"`
+				Count   int         `json:"count"`
+				Records []DNSRecord `json:"records"`
+			}
+			var result importResult
+			if len(resp.Data) == 0 || string(resp.Data) == "null" {
+				output.Infoln("DNS zone imported successfully")
+				return nil
+			}
+			if err := json.Unmarshal(resp.Data, &result); err != nil {
+				return fmt.Errorf("zone was sent but the import result could not be parsed (check 'mizban dns list --domain %d'): %w", domainID, err)
+			}
+
+			switch {
+			case result.Parsed > 0 || result.Created > 0:
+				output.Infof("DNS zone imported: %d records parsed, %d created\n", result.Parsed, result.Created)
+			case len(result.Records) > 0:
+				output.Infof("DNS zone imported: %d records created\n", len(result.Records))
+			case result.Count > 0:
+				output.Infof("DNS zone imported: %d records created\n", result.Count)
+			default:
+				output.Infoln("DNS zone imported successfully")
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&zone, "zone", "", "Zone file content")
+	cmd.Flags().StringVar(&file, "file", "", "Path to a BIND zone file")
+	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "Read the zone file from standard input")
 
 	cmd.MarkFlagRequired("domain")
-	cmd.MarkFlagRequired("zone")
+	cmd.MarkFlagsOneRequired("zone", "file", "stdin")
+	cmd.MarkFlagsMutuallyExclusive("zone", "file", "stdin")
 
 	return cmd
 }
 
+// normalizeZone converts Windows line endings, strips ";" comments (outside
+// quoted strings) and drops blank lines from a BIND zone file
+func normalizeZone(zone string) string {
+	zone = strings.ReplaceAll(zone, "\r\n", "\n")
+	zone = strings.ReplaceAll(zone, "\r", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(zone, "\n") {
+		line = strings.TrimRight(stripZoneComment(line), " \t")
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stripZoneComment removes a trailing ";" comment, ignoring semicolons
+// inside quoted TXT data such as "v=DKIM1; k=rsa"
+func stripZoneComment(line string) string {
+	inQuotes := false
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes:
+			return line[:i]
+		}
+	}
+	return line
+}
+
 func newDNSExportCmd() *cobra.Command {
 	var domainID int
+	var format, outputFile string
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export DNS zone file",
+		Long:  "Export DNS records as a BIND zone file (default), CSV or JSON.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/export", domainID))
-			if err != nil {
-				return err
+
+			var data []byte
+			switch format {
+			case "bind":
+				resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns/export", domainID))
+				if err != nil {
+					return err
+				}
+
+				var result struct {
+					Zone string `json:"zone"`
+				}
+				if err := json.Unmarshal(resp.Data, &result); err != nil {
+					return fmt.Errorf("failed to parse zone: %w", err)
+				}
+				data = []byte(strings.TrimRight(result.Zone, "\n") + "\n")
+			case "csv", "json":
+				resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns", domainID))
+				if err != nil {
+					return err
+				}
+
+				var records []DNSRecord
+				if err := json.Unmarshal(resp.Data, &records); err != nil {
+					return fmt.Errorf("failed to parse records: %w", err)
+				}
+
+				if format == "json" {
+					data, err = json.MarshalIndent(records, "", "  ")
+					if err != nil {
+						return err
+					}
+					data = append(data, '\n')
+				} else {
+					data, err = dnsRecordsCSV(records)
+					if err != nil {
+						return err
+					}
+				}
+			default:
+				return fmt.Errorf("invalid format: %s (valid: bind, csv, json)", format)
 			}
 
-			var result struct {
-				Zone string `json:"zone"`
+			if outputFile == "" {
+				_, err := os.Stdout.Write(data)
+				return err
 			}
-			if err := json.Unmarshal(resp.Data, &result); err != nil {
-				return fmt.Errorf("failed to parse zone: %w", err)
+			if err := os.WriteFile(outputFile, data, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputFile, err)
 			}
-
-			fmt.Println(result.Zone)
+			fmt.Printf("DNS records exported to %s\n", outputFile)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&format, "format", "bind", "Output format (bind/csv/json)")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the export to a file instead of stdout")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
+// dnsRecordsCSV renders records as CSV with a header row, in the columns
+// readDNSRecordsFile reads back
+func dnsRecordsCSV(records []DNSRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"type", "name", "content", "ttl", "priority", "port", "protocol", "proxy"})
+	for _, r := range records {
+		w.Write([]string{
+			r.Type, r.Name, r.Content, strconv.Itoa(r.TTL), strconv.Itoa(r.Priority),
+			strconv.Itoa(int(r.Port)), r.Protocol, proxyState(r.Proxied()),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 func newDNSFetchRecordsCmd() *cobra.Command {
 	var domainID int
 
@@ -422,41 +1152,49 @@ func newDNSFetchRecordsCmd() *cobra.Command {
 		Short: "Fetch DNS records from authoritative nameservers",
 		Long:  "Automatically discover and import DNS records from the current authoritative nameservers.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := api.NewClient()
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/fetch-records", domainID), nil)
-			if err != nil {
-				return err
-			}
-
-			var result struct {
-				Records []DNSRecord `json:"records"`
-				Count   int         `json:"count"`
-			}
-			if err := json.Unmarshal(resp.Data, &result); err != nil {
-				fmt.Println("DNS records fetched successfully")
-				return nil
-			}
-
-			fmt.Printf("Fetched %d DNS records from authoritative nameservers\n", result.Count)
-			if len(result.Records) > 0 {
-				fmt.Printf("\n%-6s %-8s %-25s %-40s\n", "ID", "TYPE", "NAME", "CONTENT")
-				fmt.Println(strings.Repeat("-", 85))
-				for _, r := range result.Records {
-					fmt.Printf("%-6d %-8s %-25s %-40s\n",
-						r.ID, r.Type, truncate(r.Name, 25), truncate(r.Content, 40))
-				}
-			}
-
-			return nil
+			return fetchDNSRecords(api.NewClient(), domainID)
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
+// fetchDNSRecords imports records from the domain's current nameservers
+func fetchDNSRecords(client *api.Client, domainID int) error {
+	resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns/fetch-records", domainID), nil)
+	if err != nil {
+		return err
+	}
+
+	type fetchResult struct {
+		Records []DNSRecord `json:"records"`
+		Count   int         `json:"count"`
+	}
+	var result fetchResult
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		output.Infoln("DNS records fetched successfully")
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("records were fetched but the result could not be parsed (check 'mizban dns list --domain %d'): %w", domainID, err)
+	}
+
+	output.Infof("Fetched %d DNS records from authoritative nameservers\n", result.Count)
+	if len(result.Records) > 0 {
+		fmt.Println()
+		table := output.NewTable("ID", "TYPE", "NAME", "CONTENT")
+		for _, r := range result.Records {
+			table.AddRow(r.ID, r.Type, r.Name, r.Content)
+		}
+		table.Render()
+	}
+
+	return nil
+}
+
 func newDNSCustomNSCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "custom-ns",
@@ -480,14 +1218,13 @@ func newDNSCustomNSGetCmd() *cobra.Command {
 		Short: "Get custom nameserver configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/custom-ns", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns/custom-ns", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var ns struct {
@@ -496,13 +1233,12 @@ func newDNSCustomNSGetCmd() *cobra.Command {
 				Enabled bool   `json:"enabled"`
 			}
 			if err := json.Unmarshal(resp.Data, &ns); err != nil {
-				fmt.Println(string(resp.Data))
-				return nil
+				return fmt.Errorf("failed to parse nameservers: %w", err)
 			}
 
 			fmt.Printf("Custom Nameservers\n")
 			fmt.Printf("==================\n")
-			fmt.Printf("Enabled: %v\n", ns.Enabled)
+			fmt.Printf("Enabled: %s\n", types.FormatBool(ns.Enabled))
 			if ns.NS1 != "" {
 				fmt.Printf("NS1:     %s\n", ns.NS1)
 			}
@@ -514,7 +1250,7 @@ func newDNSCustomNSGetCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -530,7 +1266,7 @@ func newDNSCustomNSSetCmd() *cobra.Command {
 		Short: "Set custom nameservers",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/custom-ns", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns/custom-ns", domainID), map[string]interface{}{
 				"ns1": ns1,
 				"ns2": ns2,
 			})
@@ -538,14 +1274,14 @@ func newDNSCustomNSSetCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Custom nameservers configured successfully")
-			fmt.Printf("NS1: %s\n", ns1)
-			fmt.Printf("NS2: %s\n", ns2)
+			output.Infoln("Custom nameservers configured successfully")
+			output.Infof("NS1: %s\n", ns1)
+			output.Infof("NS2: %s\n", ns2)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&ns1, "ns1", "", "Primary nameserver")
 	cmd.Flags().StringVar(&ns2, "ns2", "", "Secondary nameserver")
 	cmd.MarkFlagRequired("domain")
@@ -563,17 +1299,17 @@ func newDNSCustomNSDeleteCmd() *cobra.Command {
 		Short: "Remove custom nameservers",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/custom-ns", domainID))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/dns/custom-ns", domainID))
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Custom nameservers removed successfully")
+			output.Infoln("Custom nameservers removed successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -602,14 +1338,13 @@ func newDNSSECStatusCmd() *cobra.Command {
 		Short: "Get DNSSEC status",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/dnssec", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/dns/dnssec", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var dnssec struct {
@@ -621,13 +1356,12 @@ func newDNSSECStatusCmd() *cobra.Command {
 				Digest    string `json:"digest"`
 			}
 			if err := json.Unmarshal(resp.Data, &dnssec); err != nil {
-				fmt.Println(string(resp.Data))
-				return nil
+				return fmt.Errorf("failed to parse DNSSEC status: %w", err)
 			}
 
 			fmt.Printf("DNSSEC Configuration\n")
 			fmt.Printf("====================\n")
-			fmt.Printf("Enabled:     %v\n", dnssec.Enabled)
+			fmt.Printf("Enabled:     %s\n", types.FormatBool(dnssec.Enabled))
 			if dnssec.Enabled {
 				fmt.Printf("Algorithm:   %s\n", dnssec.Algorithm)
 				fmt.Printf("Key Tag:     %d\n", dnssec.KeyTag)
@@ -641,7 +1375,7 @@ func newDNSSECStatusCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -656,7 +1390,7 @@ func newDNSSECEnableCmd() *cobra.Command {
 		Short: "Enable DNSSEC",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/dnssec", domainID), map[string]interface{}{
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns/dnssec", domainID), map[string]interface{}{
 				"enabled": true,
 			})
 			if err != nil {
@@ -667,18 +1401,18 @@ func newDNSSECEnableCmd() *cobra.Command {
 				DS string `json:"ds"`
 			}
 			if err := json.Unmarshal(resp.Data, &dnssec); err == nil && dnssec.DS != "" {
-				fmt.Println("DNSSEC enabled successfully!")
-				fmt.Println("\nAdd this DS record to your registrar:")
-				fmt.Println(dnssec.DS)
+				output.Infoln("DNSSEC enabled successfully!")
+				output.Infoln("\nAdd this DS record to your registrar:")
+				output.Infoln(dnssec.DS)
 			} else {
-				fmt.Println("DNSSEC enabled successfully")
+				output.Infoln("DNSSEC enabled successfully")
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -692,20 +1426,20 @@ func newDNSSECDisableCmd() *cobra.Command {
 		Short: "Disable DNSSEC",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/dns/dnssec", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/dns/dnssec", domainID), map[string]interface{}{
 				"enabled": false,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("DNSSEC disabled successfully")
-			fmt.Println("Remember to remove the DS record from your registrar")
+			output.Infoln("DNSSEC disabled successfully")
+			output.Infoln("Remember to remove the DS record from your registrar")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -0,0 +1,106 @@
+package cdn
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mizbancloud/cli/pkg/types"
+)
+
+func changedFlags(names ...string) func(string) bool {
+	return func(flag string) bool {
+		for _, n := range names {
+			if n == flag {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestMergeDNSRecordUpdate(t *testing.T) {
+	current := DNSRecord{
+		ID:       7,
+		Type:     "A",
+		Name:     "www",
+		Content:  "203.0.113.10",
+		TTL:      300,
+		Port:     8443,
+		Protocol: "HTTPS",
+		Proxy:    "ACTIVE",
+	}
+	// What the update command's flags hold when only --name is passed:
+	// zero values for everything else, and proxy off
+	update := DNSRecord{Name: "app", Proxy: proxyState(false)}
+
+	t.Run("only name", func(t *testing.T) {
+		got := mergeDNSRecordUpdate(current, update, changedFlags("name"))
+		want := current
+		want.Name = "app"
+		if got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+
+		body := got.updateBody()
+		if body["ttl"] != 300 || body["protocol"] != "HTTPS" || body["proxy"] != true || body["port"] != types.FlexibleInt(8443) {
+			t.Errorf("update body lost fields: %v", body)
+		}
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		if got := mergeDNSRecordUpdate(current, update, changedFlags()); got != current {
+			t.Errorf("got %+v, want %+v", got, current)
+		}
+	})
+
+	t.Run("proxy turned off", func(t *testing.T) {
+		got := mergeDNSRecordUpdate(current, update, changedFlags("proxy"))
+		if got.Proxied() {
+			t.Errorf("proxy should be off, got %q", got.Proxy)
+		}
+		if got.TTL != 300 || got.Protocol != "HTTPS" || got.Name != "www" {
+			t.Errorf("other fields changed: %+v", got)
+		}
+	})
+
+	t.Run("every field", func(t *testing.T) {
+		full := DNSRecord{Type: "AAAA", Name: "api", Content: "2001:db8::1", TTL: 60, Priority: 5, Port: 443, Protocol: "HTTP", Proxy: "INACTIVE"}
+		got := mergeDNSRecordUpdate(current, full, changedFlags("type", "name", "destination", "ttl", "priority", "port", "protocol", "proxy"))
+		full.ID = current.ID
+		if got != full {
+			t.Errorf("got %+v, want %+v", got, full)
+		}
+	})
+}
+
+func TestDNSRecordsCSVRoundTrip(t *testing.T) {
+	records := []DNSRecord{
+		{ID: 1, Type: "A", Name: "www", Content: "192.0.2.1", TTL: 300, Protocol: "HTTPS", Proxy: "ACTIVE"},
+		{ID: 2, Type: "MX", Name: "@", Content: "mail.example.com", TTL: 3600, Priority: 10, Proxy: "INACTIVE"},
+		{ID: 3, Type: "SRV", Name: "_sip._tcp", Content: "sip.example.com", TTL: 3600, Priority: 5, Port: 5060, Proxy: "INACTIVE"},
+	}
+
+	data, err := dnsRecordsCSV(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "records.csv")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err := readDNSRecordsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(records) {
+		t.Fatalf("read %d records, want %d", len(got), len(records))
+	}
+	for i := range records {
+		if want := records[i].createBody(); !reflect.DeepEqual(got[i].createBody(), want) {
+			t.Errorf("record %d: got %v, want %v", i+1, got[i].createBody(), want)
+		}
+	}
+}
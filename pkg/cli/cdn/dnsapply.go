@@ -0,0 +1,385 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/types"
+)
+
+// zoneRecord is one record in a dns apply file or a dns add --from-file
+// JSON file
+type zoneRecord struct {
+	Type     string            `yaml:"type" json:"type"`
+	Name     string            `yaml:"name" json:"name"`
+	Content  string            `yaml:"content" json:"content"`
+	TTL      int               `yaml:"ttl" json:"ttl"`
+	Priority int               `yaml:"priority" json:"priority"`
+	Port     types.FlexibleInt `yaml:"port" json:"port"`
+	Protocol string            `yaml:"protocol" json:"protocol"`
+	Proxy    proxySetting      `yaml:"proxy" json:"proxy"`
+}
+
+// record converts z to a DNSRecord, defaulting the TTL to an hour
+func (z zoneRecord) record() DNSRecord {
+	r := DNSRecord{
+		Type:     strings.ToUpper(z.Type),
+		Name:     z.Name,
+		Content:  z.Content,
+		TTL:      z.TTL,
+		Priority: z.Priority,
+		Port:     z.Port,
+		Protocol: normalizeProtocol(z.Protocol),
+		Proxy:    proxyState(bool(z.Proxy)),
+	}
+	if r.TTL == 0 {
+		r.TTL = 3600
+	}
+	return r
+}
+
+// proxySetting is the proxy field of a record file. It takes a boolean or
+// a proxy state as the API and dns export write it ("ACTIVE"/"INACTIVE").
+type proxySetting bool
+
+// parseProxySetting reads the text forms a proxySetting accepts
+func parseProxySetting(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "false", "no", "0", "inactive":
+		return false, nil
+	case "true", "yes", "1", "active":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid proxy %q: use true/false or ACTIVE/INACTIVE", s)
+}
+
+func (p *proxySetting) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*p = proxySetting(b)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid proxy %s: use true/false or ACTIVE/INACTIVE", data)
+	}
+	v, err := parseProxySetting(s)
+	if err != nil {
+		return err
+	}
+	*p = proxySetting(v)
+	return nil
+}
+
+func (p *proxySetting) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: invalid proxy: use true/false or ACTIVE/INACTIVE", node.Line)
+	}
+	if node.Tag == "!!null" {
+		*p = false
+		return nil
+	}
+	v, err := parseProxySetting(node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	*p = proxySetting(v)
+	return nil
+}
+
+// zoneFile is the dns apply file: a list of records, either bare or under
+// a "records" key
+type zoneFile struct {
+	Records []zoneRecord `yaml:"records"`
+}
+
+// dnsChange is one step of a dns apply plan
+type dnsChange struct {
+	action  string // create, update or delete
+	record  DNSRecord
+	details []string
+}
+
+func newDNSApplyCmd() *cobra.Command {
+	var domainID int
+	var file string
+	var prune, autoApprove bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Make a domain's DNS records match a YAML or JSON file",
+		Long: `Reconcile the live zone with the records declared in a YAML or JSON file:
+missing records are created and changed ones updated in place. With --prune,
+records that aren't in the file are deleted (SOA and apex NS records never are).
+
+The plan is printed first and applied after confirmation, or straight away
+with --auto-approve. With --dry-run only the plan is printed.
+
+File format (JSON works too):
+
+  records:
+    - type: A
+      name: www
+      content: 203.0.113.10
+      ttl: 300
+      proxy: true
+    - type: MX
+      name: "@"
+      content: mail.example.com
+      priority: 10
+
+proxy takes true/false or ACTIVE/INACTIVE, as dns export writes it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, err := readZoneFile(file)
+			if err != nil {
+				return err
+			}
+
+			client := api.NewClient()
+			live, err := getDNSRecords(client, domainID)
+			if err != nil {
+				return err
+			}
+
+			changes, kept := planDNSChanges(live, desired, prune)
+			if len(changes) == 0 {
+				output.Infof("No changes: the zone already matches %s\n", file)
+				return nil
+			}
+
+			printDNSPlan(changes)
+			switch {
+			case kept > 0 && prune:
+				output.Infof("%d SOA/NS records managed by the CDN are left as they are\n", kept)
+			case kept > 0:
+				output.Infof("%d live records are not in %s and are left as they are (use --prune to delete them)\n", kept, file)
+			}
+			if config.GetConfig().DryRun {
+				return nil
+			}
+
+			if !autoApprove {
+				output.Infoln()
+				ok, err := prompt.Confirm("Apply these changes?")
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
+				}
+			}
+
+			output.Infoln()
+			return applyDNSChanges(client, domainID, changes)
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&file, "file", "", "YAML or JSON file declaring the zone's records")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Delete live records that aren't in the file")
+	cmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Apply the plan without asking for confirmation")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// readZoneFile loads and validates the records of a dns apply file
+func readZoneFile(path string) ([]DNSRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zone file: %w", err)
+	}
+
+	// JSON is valid YAML, so one parser handles both formats
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var zone zoneFile
+	var target interface{} = &zone
+	if len(root.Content) > 0 && root.Content[0].Kind == yaml.SequenceNode {
+		target = &zone.Records
+	}
+	if err := root.Decode(target); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(zone.Records) == 0 {
+		return nil, fmt.Errorf("no records found in %s", path)
+	}
+
+	records := make([]DNSRecord, 0, len(zone.Records))
+	for i, z := range zone.Records {
+		r := z.record()
+		if err := validateDNSRecordInput(r); err != nil {
+			return nil, fmt.Errorf("%s: record %d: %w", path, i+1, err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// dnsRecordKey groups records that share a name and type
+func dnsRecordKey(r DNSRecord) string {
+	return strings.ToUpper(r.Type) + " " + strings.ToLower(strings.TrimSuffix(r.Name, "."))
+}
+
+// planDNSChanges works out how to turn live into desired. Within each name
+// and type, records with identical content are matched first; the rest are
+// paired up and updated in place, like dns upsert, and any left over are
+// created or (with prune) deleted. kept counts live records left alone
+// because prune is off.
+func planDNSChanges(live, desired []DNSRecord, prune bool) (changes []dnsChange, kept int) {
+	liveByKey := map[string][]DNSRecord{}
+	var keys []string
+	for _, r := range live {
+		key := dnsRecordKey(r)
+		if _, ok := liveByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		liveByKey[key] = append(liveByKey[key], r)
+	}
+	desiredByKey := map[string][]DNSRecord{}
+	for _, r := range desired {
+		key := dnsRecordKey(r)
+		if _, ok := desiredByKey[key]; !ok {
+			if _, ok := liveByKey[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		desiredByKey[key] = append(desiredByKey[key], r)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		current := liveByKey[key]
+		var unmatched []DNSRecord
+		for _, want := range desiredByKey[key] {
+			i := indexByContent(current, want.Content)
+			if i < 0 {
+				unmatched = append(unmatched, want)
+				continue
+			}
+			if change, ok := updateChange(current[i], want); ok {
+				changes = append(changes, change)
+			}
+			current = append(current[:i:i], current[i+1:]...)
+		}
+
+		for len(unmatched) > 0 && len(current) > 0 {
+			if change, ok := updateChange(current[0], unmatched[0]); ok {
+				changes = append(changes, change)
+			}
+			unmatched, current = unmatched[1:], current[1:]
+		}
+		for _, want := range unmatched {
+			changes = append(changes, dnsChange{action: "create", record: want})
+		}
+		for _, r := range current {
+			if !prune || isProtectedDNSRecord(r) {
+				kept++
+				continue
+			}
+			changes = append(changes, dnsChange{action: "delete", record: r})
+		}
+	}
+	return changes, kept
+}
+
+func indexByContent(records []DNSRecord, content string) int {
+	for i, r := range records {
+		if r.Content == content {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateChange turns a live record into the desired one, keeping its ID
+func updateChange(current, want DNSRecord) (dnsChange, bool) {
+	updated := current
+	updated.Content = want.Content
+	updated.TTL = want.TTL
+	updated.Priority = want.Priority
+	updated.Port = want.Port
+	updated.Protocol = want.Protocol
+	updated.Proxy = want.Proxy
+
+	details := dnsRecordDiff(current, updated)
+	if len(details) == 0 {
+		return dnsChange{}, false
+	}
+	return dnsChange{action: "update", record: updated, details: details}, true
+}
+
+// isProtectedDNSRecord reports records --prune must never delete because
+// the CDN manages them
+func isProtectedDNSRecord(r DNSRecord) bool {
+	if strings.EqualFold(r.Type, "SOA") {
+		return true
+	}
+	return strings.EqualFold(r.Type, "NS") && (r.Name == "@" || r.Name == "")
+}
+
+// dnsActionLabels mark each action in the plan and report its outcome
+var dnsActionLabels = map[string][2]string{
+	"create": {"+ create", "Created"},
+	"update": {"~ update", "Updated"},
+	"delete": {"- delete", "Deleted"},
+}
+
+func printDNSPlan(changes []dnsChange) {
+	counts := map[string]int{}
+	table := output.NewTable("ACTION", "ID", "TYPE", "NAME", "CONTENT", "CHANGES")
+	for _, c := range changes {
+		counts[c.action]++
+		id := "-"
+		if c.record.ID != 0 {
+			id = fmt.Sprint(c.record.ID)
+		}
+		table.AddRow(dnsActionLabels[c.action][0], id, c.record.Type, c.record.Name, c.record.Content, strings.Join(c.details, ", "))
+	}
+	table.Render()
+	output.Infof("\nPlan: %d to create, %d to update, %d to delete\n", counts["create"], counts["update"], counts["delete"])
+}
+
+// applyDNSChanges carries out a plan, continuing past failures
+func applyDNSChanges(client *api.Client, domainID int, changes []dnsChange) error {
+	failed := 0
+	progress := output.NewProgress(len(changes))
+	for _, c := range changes {
+		progress.Step(fmt.Sprintf("%s %s %s", c.action, c.record.Type, c.record.Name))
+		var err error
+		switch c.action {
+		case "create":
+			_, err = createDNSRecord(client, domainID, c.record)
+		case "update":
+			err = updateDNSRecord(client, domainID, c.record)
+		case "delete":
+			_, err = client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/dns/%d", domainID, c.record.ID))
+		}
+		progress.Clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to %s %s record %s: %v\n", c.action, c.record.Type, c.record.Name, err)
+			failed++
+			continue
+		}
+		output.Infof("%s %s record %s\n", dnsActionLabels[c.action][1], c.record.Type, c.record.Name)
+	}
+
+	output.Infof("\nApplied %d of %d changes\n", len(changes)-failed, len(changes))
+	if failed > 0 {
+		return fmt.Errorf("%d changes could not be applied", failed)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cdn
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRecordFileProxy(t *testing.T) {
+	yamlZone := `records:
+  - {type: A, name: a, content: 192.0.2.1, proxy: true}
+  - {type: A, name: b, content: 192.0.2.2, proxy: ACTIVE}
+  - {type: A, name: c, content: 192.0.2.3, proxy: INACTIVE}
+  - {type: A, name: d, content: 192.0.2.4}
+`
+	jsonRecords := `[
+  {"type": "A", "name": "a", "content": "192.0.2.1", "proxy": true},
+  {"type": "A", "name": "b", "content": "192.0.2.2", "proxy": "ACTIVE"},
+  {"type": "A", "name": "c", "content": "192.0.2.3", "proxy": "INACTIVE"},
+  {"type": "A", "name": "d", "content": "192.0.2.4"}
+]`
+	want := []bool{true, true, false, false}
+
+	check := func(t *testing.T, records []DNSRecord) {
+		t.Helper()
+		if len(records) != len(want) {
+			t.Fatalf("read %d records, want %d", len(records), len(want))
+		}
+		for i, r := range records {
+			if r.Proxied() != want[i] {
+				t.Errorf("record %s: proxied = %v, want %v", r.Name, r.Proxied(), want[i])
+			}
+		}
+	}
+
+	t.Run("dns apply yaml", func(t *testing.T) {
+		records, err := readZoneFile(writeTestFile(t, "zone.yaml", yamlZone))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, records)
+	})
+
+	t.Run("dns apply json", func(t *testing.T) {
+		records, err := readZoneFile(writeTestFile(t, "zone.json", jsonRecords))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, records)
+	})
+
+	t.Run("dns add from file", func(t *testing.T) {
+		records, _, err := readDNSRecordsFile(writeTestFile(t, "records.json", jsonRecords))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, records)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := readZoneFile(writeTestFile(t, "zone.yaml", "- {type: A, name: a, content: 192.0.2.1, proxy: maybe}\n"))
+		if err == nil || !strings.Contains(err.Error(), `invalid proxy "maybe"`) {
+			t.Errorf("err = %v, want an invalid proxy error", err)
+		}
+		_, _, err = readDNSRecordsFile(writeTestFile(t, "records.json", `[{"type": "A", "name": "a", "content": "192.0.2.1", "proxy": "maybe"}]`))
+		if err == nil || !strings.Contains(err.Error(), `invalid proxy "maybe"`) {
+			t.Errorf("err = %v, want an invalid proxy error", err)
+		}
+	})
+}
@@ -2,13 +2,20 @@ package cdn
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
 	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type Domain struct {
@@ -30,10 +37,10 @@ type Domain struct {
 }
 
 type Nameserver struct {
-	NS1 string               `json:"ns1"`
-	NS2 string               `json:"ns2"`
-	IP1 types.FlexibleString `json:"ip1"`
-	IP2 types.FlexibleString `json:"ip2"`
+	NS1 string                    `json:"ns1"`
+	NS2 string                    `json:"ns2"`
+	IP1 types.FlexibleStringSlice `json:"ip1"`
+	IP2 types.FlexibleStringSlice `json:"ip2"`
 }
 
 type CurrentNameserver struct {
@@ -63,26 +70,20 @@ func NewDomainCmd() *cobra.Command {
 
 func newDomainListCmd() *cobra.Command {
 	var jsonOutput bool
+	var pageOpts api.PageOptions
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all domains",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cdn/ng/domains")
+			domains, meta, err := api.GetList[Domain](client, "/v1/cdn/ng/domains", pageOpts)
 			if err != nil {
 				return err
 			}
 
-			var domains []Domain
-			if err := json.Unmarshal(resp.Data, &domains); err != nil {
-				return fmt.Errorf("failed to parse domains: %w", err)
-			}
-
 			if jsonOutput {
-				output, _ := json.MarshalIndent(domains, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(domains)
 			}
 
 			if len(domains) == 0 {
@@ -90,19 +91,17 @@ func newDomainListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-30s %-12s %-15s %-6s\n", "ID", "DOMAIN", "STATUS", "PLAN", "WAF")
-			fmt.Println(strings.Repeat("-", 75))
+			table := output.NewTable("ID", "DOMAIN", "STATUS", "PLAN", "WAF")
 			for _, d := range domains {
-				waf := "No"
-				if d.WAFEnabled.Bool() {
-					waf = "Yes"
-				}
 				domainName := d.Name
 				if domainName == "" {
 					domainName = d.Domain
 				}
-				fmt.Printf("%-6d %-30s %-12s %-15s %-6s\n",
-					d.ID, truncate(domainName, 30), d.Status, d.PlanDisplayName, waf)
+				table.AddRow(d.ID, domainName, d.Status, d.PlanDisplayName, d.WAFEnabled)
+			}
+			table.Render()
+			if meta != nil {
+				fmt.Printf("\n%s\n", meta.Footer(len(domains)))
 			}
 
 			return nil
@@ -110,17 +109,40 @@ func newDomainListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().IntVar(&pageOpts.Page, "page", 0, "Page number to fetch")
+	cmd.Flags().IntVar(&pageOpts.PerPage, "per-page", 0, "Number of items per page")
+	cmd.Flags().BoolVar(&pageOpts.All, "all", false, "Fetch every page")
 
 	return cmd
 }
 
 func newDomainAddCmd() *cobra.Command {
 	var domain string
+	var waitActive bool
+	var waitTimeout, interval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "add",
 		Short: "Add a new domain",
+		Long: `Add a new domain to the CDN.
+
+Run without --domain in a terminal to be asked for the domain name, and
+then offered to import its existing DNS records and request a free SSL
+certificate.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			interactive := false
+			if domain == "" {
+				if !prompt.IsInteractive() {
+					return fmt.Errorf("required flag(s) \"domain\" not set")
+				}
+				interactive = true
+				name, err := prompt.Input("Domain name (e.g. example.com)")
+				if err != nil {
+					return err
+				}
+				domain = strings.ToLower(name)
+			}
+
 			client := api.NewClient()
 
 			resp, err := client.Post("/v1/cdn/ng/domains", map[string]string{"domain": domain})
@@ -133,63 +155,103 @@ func newDomainAddCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse domain: %w", err)
 			}
 
-			fmt.Printf("Domain added successfully!\n")
-			fmt.Printf("ID: %d\n", result.ID)
-			fmt.Printf("Domain: %s\n", result.Name)
-			fmt.Printf("Status: %s\n", result.Status)
+			output.Infof("Domain added successfully!\n")
+			output.Infof("ID: %d\n", result.ID)
+			output.PrintID(result.ID)
+			output.Infof("Domain: %s\n", result.Name)
+			output.Infof("Status: %s\n", result.Status)
 			if result.Nameservers != nil {
-				fmt.Println("\nNameservers (point your domain to these):")
-				fmt.Printf("  - %s\n", result.Nameservers.NS1)
-				fmt.Printf("  - %s\n", result.Nameservers.NS2)
+				output.Infoln("\nNameservers (point your domain to these):")
+				output.Infof("  - %s\n", result.Nameservers.NS1)
+				output.Infof("  - %s\n", result.Nameservers.NS2)
+			}
+
+			if waitActive {
+				output.Infoln()
+				active, err := waitForDomainActive(client, result.ID, waitTimeout, interval)
+				if err != nil {
+					return err
+				}
+				if strings.EqualFold(active.Status, "active") {
+					output.Infof("Domain %s is active\n", active.displayName())
+				} else {
+					output.Infof("Nameservers for %s now point to Mizban (status: %s)\n", active.displayName(), active.Status)
+				}
 			}
 
+			if interactive {
+				return runDomainAddFollowUps(client, &result)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&domain, "domain", "", "Domain name to add")
-	cmd.MarkFlagRequired("domain")
+	cmd.Flags().BoolVar(&waitActive, "wait-active", false, "Wait until the nameservers are detected and the domain is active")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", time.Hour, "How long --wait-active waits (0 waits forever)")
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "How often --wait-active checks the domain")
 
 	return cmd
 }
 
+// runDomainAddFollowUps offers the usual first steps after an interactive
+// domain add. Failures are reported but don't undo the added domain.
+func runDomainAddFollowUps(client *api.Client, d *Domain) error {
+	fmt.Println()
+	ok, err := prompt.Confirm(fmt.Sprintf("Import the existing DNS records of %s from its current nameservers?", d.displayName()))
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := fetchDNSRecords(client, d.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to import DNS records: %v\nRetry with: mizban dns fetch-records --domain %d\n", err, d.ID)
+		}
+	}
+
+	fmt.Println()
+	ok, err = prompt.Confirm("Request a free Let's Encrypt SSL certificate?")
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := requestFreeSSL(client, d.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to request a certificate: %v\nRetry with: mizban ssl request-free --domain %d\n", err, d.ID)
+		}
+	}
+	return nil
+}
+
 func newDomainGetCmd() *cobra.Command {
 	var jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use:   "get [domain-id]",
-		Short: "Get domain details",
-		Args:  cobra.ExactArgs(1),
+		Use:               "get [domain-id|name]",
+		Short:             "Get domain details",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomainArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cdn/ng/domains/" + args[0])
+			domainID, err := resolveDomainArg(client, args[0])
 			if err != nil {
 				return err
 			}
-
-			var domain Domain
-			if err := json.Unmarshal(resp.Data, &domain); err != nil {
-				return fmt.Errorf("failed to parse domain: %w", err)
+			domain, err := getDomain(client, domainID)
+			if err != nil {
+				return err
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(domain, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(domain)
 			}
 
-			domainName := domain.Name
-			if domainName == "" {
-				domainName = domain.Domain
-			}
 			fmt.Printf("ID:          %d\n", domain.ID)
-			fmt.Printf("Domain:      %s\n", domainName)
+			fmt.Printf("Domain:      %s\n", domain.displayName())
 			fmt.Printf("Status:      %s\n", domain.Status)
 			fmt.Printf("Plan:        %s (%s)\n", domain.Plan, domain.PlanDisplayName)
-			fmt.Printf("WAF:         %v\n", domain.WAFEnabled.Bool())
-			fmt.Printf("DNSSEC:      %v\n", domain.DNSSECEnabled.Bool())
-			fmt.Printf("HTTP/3:      %v\n", domain.H3Enabled.Bool())
-			fmt.Printf("WebSocket:   %v\n", domain.SupportsWebsocket.Bool())
+			fmt.Printf("WAF:         %s\n", domain.WAFEnabled)
+			fmt.Printf("DNSSEC:      %s\n", domain.DNSSECEnabled)
+			fmt.Printf("HTTP/3:      %s\n", domain.H3Enabled)
+			fmt.Printf("WebSocket:   %s\n", domain.SupportsWebsocket)
 			fmt.Printf("Added:       %s\n", domain.AddedAt)
 			if domain.CurrentNameservers != nil {
 				fmt.Println("Current Nameservers:")
@@ -198,8 +260,8 @@ func newDomainGetCmd() *cobra.Command {
 			}
 			if domain.Nameservers != nil {
 				fmt.Println("Target Nameservers:")
-				fmt.Printf("  - NS1: %s\n", domain.Nameservers.NS1)
-				fmt.Printf("  - NS2: %s\n", domain.Nameservers.NS2)
+				fmt.Printf("  - NS1: %s\n", formatNameserver(domain.Nameservers.NS1, domain.Nameservers.IP1))
+				fmt.Printf("  - NS2: %s\n", formatNameserver(domain.Nameservers.NS2, domain.Nameservers.IP2))
 			}
 
 			return nil
@@ -211,31 +273,131 @@ func newDomainGetCmd() *cobra.Command {
 	return cmd
 }
 
+func getDomain(client *api.Client, domainID int) (*Domain, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d", domainID))
+	if err != nil {
+		return nil, err
+	}
+
+	var domain Domain
+	if err := json.Unmarshal(resp.Data, &domain); err != nil {
+		return nil, fmt.Errorf("failed to parse domain: %w", err)
+	}
+	return &domain, nil
+}
+
+func (d *Domain) displayName() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.Domain
+}
+
+// nameserversDelegated reports whether the domain's current nameservers
+// are the ones it was asked to point to
+func (d *Domain) nameserversDelegated() bool {
+	if d.Nameservers == nil || d.CurrentNameservers == nil || d.Nameservers.NS1 == "" {
+		return false
+	}
+	current := map[string]bool{
+		normalizeNameserver(d.CurrentNameservers.NS1): true,
+		normalizeNameserver(d.CurrentNameservers.NS2): true,
+	}
+	return current[normalizeNameserver(d.Nameservers.NS1)] && current[normalizeNameserver(d.Nameservers.NS2)]
+}
+
+func normalizeNameserver(ns string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(ns)), ".")
+}
+
+// waitForDomainActive polls a domain until it is active or its
+// nameservers point to the target ones
+func waitForDomainActive(client *api.Client, domainID int, timeout, interval time.Duration) (*Domain, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid --interval %s: must be positive", interval)
+	}
+
+	start := time.Now()
+	line := output.NewStatusLine()
+	var domain Domain
+
+	err := util.Poll(client.Context(), timeout, interval, func() (bool, error) {
+		current, err := getDomain(client, domainID)
+		if err != nil {
+			return false, err
+		}
+		domain = *current
+
+		if strings.EqualFold(domain.Status, "active") || domain.nameserversDelegated() {
+			return true, nil
+		}
+
+		currentNS, targetNS := "none detected", "unknown"
+		if domain.CurrentNameservers != nil && domain.CurrentNameservers.NS1 != "" {
+			currentNS = domain.CurrentNameservers.NS1 + ", " + domain.CurrentNameservers.NS2
+		}
+		if domain.Nameservers != nil {
+			targetNS = domain.Nameservers.NS1 + ", " + domain.Nameservers.NS2
+		}
+		line.Update(fmt.Sprintf("Waiting for %s to become active (status: %s, NS: %s, target: %s, %s elapsed)",
+			domain.displayName(), domain.Status, currentNS, targetNS, time.Since(start).Round(time.Second)))
+		return false, nil
+	})
+	line.Done()
+
+	if errors.Is(err, util.ErrTimeout) {
+		return nil, fmt.Errorf("timed out after %s waiting for domain %d to become active (last status: %s)",
+			timeout, domainID, domain.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
+// formatNameserver appends the nameserver's IP addresses, when known.
+func formatNameserver(host string, ips types.FlexibleStringSlice) string {
+	if len(ips) == 0 {
+		return host
+	}
+	return fmt.Sprintf("%s (%s)", host, ips)
+}
+
 func newDomainDeleteCmd() *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:   "delete [domain-id]",
-		Short: "Delete a domain",
-		Args:  cobra.ExactArgs(1),
+		Use:               "delete [domain-id|name]",
+		Short:             "Delete a domain",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomainArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			domainID, err := resolveDomainArg(client, args[0])
+			if err != nil {
+				return err
+			}
+
 			if !force {
-				fmt.Printf("Are you sure you want to delete domain %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete domain %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
-			client := api.NewClient()
-			_, err := client.Delete("/v1/cdn/ng/domains/" + args[0])
+			_, err = client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d", domainID))
+			if api.IsNotFound(err) {
+				fmt.Println("Domain already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Domain deleted successfully")
+			output.Infoln("Domain deleted successfully")
 			return nil
 		},
 	}
@@ -245,79 +407,118 @@ func newDomainDeleteCmd() *cobra.Command {
 	return cmd
 }
 
+// DomainUsage is a domain's traffic over a --period, with a time series
+// when the API breaks the period down
+type DomainUsage struct {
+	Traffic   int64        `json:"traffic"`
+	Requests  int64        `json:"requests"`
+	Bandwidth int64        `json:"bandwidth"`
+	Series    []UsagePoint `json:"series,omitempty"`
+}
+
+type UsagePoint struct {
+	Time     string `json:"time"`
+	Traffic  int64  `json:"traffic"`
+	Requests int64  `json:"requests"`
+}
+
 func newDomainUsageCmd() *cobra.Command {
 	var period string
+	var si, jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use:   "usage [domain-id]",
-		Short: "Get domain traffic usage",
-		Args:  cobra.ExactArgs(1),
+		Use:               "usage [domain-id|name]",
+		Short:             "Get domain traffic usage",
+		Long:              "Show a domain's traffic, requests and bandwidth over the last --period. When the API returns a time series, traffic and requests are also drawn as sparklines.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomainArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateChoice("period", period, "hour", "day", "week", "month"); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cdn/ng/domains/" + args[0] + "/usage")
+			domainID, err := resolveDomainArg(client, args[0])
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			query.Set("period", period)
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/usage", domainID) + "?" + query.Encode())
 			if err != nil {
 				return err
 			}
 
-			var usage struct {
-				Traffic   int64 `json:"traffic"`
-				Requests  int64 `json:"requests"`
-				Bandwidth int64 `json:"bandwidth"`
+			if jsonOutput {
+				return output.PrintJSON(resp.Data, true)
 			}
+
+			var usage DomainUsage
 			if err := json.Unmarshal(resp.Data, &usage); err != nil {
 				return fmt.Errorf("failed to parse usage: %w", err)
 			}
 
-			fmt.Printf("Traffic:   %s\n", formatBytes(usage.Traffic))
+			fmt.Printf("Period:    last %s\n", period)
+			fmt.Printf("Traffic:   %s\n", util.FormatBytes(usage.Traffic, byteUnits(si)))
 			fmt.Printf("Requests:  %d\n", usage.Requests)
-			fmt.Printf("Bandwidth: %s/s\n", formatBytes(usage.Bandwidth))
+			fmt.Printf("Bandwidth: %s/s\n", util.FormatBytes(usage.Bandwidth, byteUnits(si)))
+
+			if len(usage.Series) > 1 {
+				traffic := make([]int64, len(usage.Series))
+				requests := make([]int64, len(usage.Series))
+				var peak int64
+				for i, p := range usage.Series {
+					traffic[i], requests[i] = p.Traffic, p.Requests
+					if p.Traffic > peak {
+						peak = p.Traffic
+					}
+				}
+				first, last := usage.Series[0].Time, usage.Series[len(usage.Series)-1].Time
+				fmt.Printf("\nTraffic   %s  peak %s\n", output.Sparkline(traffic), util.FormatBytes(peak, byteUnits(si)))
+				fmt.Printf("Requests  %s\n", output.Sparkline(requests))
+				fmt.Printf("          %s .. %s\n", first, last)
+			}
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&period, "period", "day", "Time period (hour/day/week/month)")
+	cmd.Flags().BoolVar(&si, "si", false, "Use decimal SI units (1 KB = 1000 B) instead of binary units")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	return cmd
 }
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+// byteUnits maps the --si flag to the unit system used for traffic figures.
+func byteUnits(si bool) util.ByteUnits {
+	if si {
+		return util.SI
 	}
-	return s[:max-3] + "..."
-}
-
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.2f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	return util.IEC
 }
 
 func newDomainWhoisCmd() *cobra.Command {
 	var jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use:   "whois [domain-id]",
-		Short: "Get domain WHOIS information",
-		Args:  cobra.ExactArgs(1),
+		Use:               "whois [domain-id|name]",
+		Short:             "Get domain WHOIS information",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomainArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cdn/ng/domains/" + args[0] + "/whois")
+			domainID, err := resolveDomainArg(client, args[0])
+			if err != nil {
+				return err
+			}
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/whois", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var whois struct {
@@ -328,9 +529,7 @@ func newDomainWhoisCmd() *cobra.Command {
 				Status        string `json:"status"`
 			}
 			if err := json.Unmarshal(resp.Data, &whois); err != nil {
-				// If parsing fails, just print raw data
-				fmt.Println(string(resp.Data))
-				return nil
+				return fmt.Errorf("failed to parse WHOIS data: %w", err)
 			}
 
 			fmt.Printf("WHOIS Information\n")
@@ -358,14 +557,14 @@ func newDomainWhoisCmd() *cobra.Command {
 func newDomainReportsCmd() *cobra.Command {
 	var domainID int
 	var period string
-	var jsonOutput bool
+	var jsonOutput, si bool
 
 	cmd := &cobra.Command{
 		Use:   "reports",
 		Short: "Get domain traffic reports",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/reports", domainID), map[string]interface{}{
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/reports", domainID), map[string]interface{}{
 				"period": period,
 			})
 			if err != nil {
@@ -373,8 +572,7 @@ func newDomainReportsCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var reports struct {
@@ -384,24 +582,24 @@ func newDomainReportsCmd() *cobra.Command {
 				BandwidthPeak  int64 `json:"bandwidth_peak"`
 			}
 			if err := json.Unmarshal(resp.Data, &reports); err != nil {
-				fmt.Println(string(resp.Data))
-				return nil
+				return fmt.Errorf("failed to parse reports: %w", err)
 			}
 
 			fmt.Printf("Domain Reports (%s)\n", period)
 			fmt.Printf("====================\n")
-			fmt.Printf("Total Traffic:   %s\n", formatBytes(reports.TotalTraffic))
+			fmt.Printf("Total Traffic:   %s\n", util.FormatBytes(reports.TotalTraffic, byteUnits(si)))
 			fmt.Printf("Total Requests:  %d\n", reports.TotalRequests)
 			fmt.Printf("Cache Hit Ratio: %.2f%%\n", reports.CacheHitRatio*100)
-			fmt.Printf("Bandwidth Peak:  %s/s\n", formatBytes(reports.BandwidthPeak))
+			fmt.Printf("Bandwidth Peak:  %s/s\n", util.FormatBytes(reports.BandwidthPeak, byteUnits(si)))
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&period, "period", "day", "Time period (hour/day/week/month)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&si, "si", false, "Use decimal SI units (1 KB = 1000 B) instead of binary units")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -413,29 +611,109 @@ func newDomainRedirectModeCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "redirect-mode",
-		Short: "Set domain redirect mode",
-		Long: `Set redirect mode for the domain:
+		Short: "Get or set domain redirect mode",
+		Long: `Get or set redirect mode for the domain:
   - none:  No redirect
   - www:   Redirect to www subdomain
-  - naked: Redirect to naked domain (without www)`,
+  - naked: Redirect to naked domain (without www)
+
+"redirect-mode --domain <id> --mode <mode>" still works as a shorthand for
+"redirect-mode set".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("mode") {
+				return cmd.Help()
+			}
+			if !cmd.Flags().Changed("domain") {
+				return fmt.Errorf("required flag(s) \"domain\" not set")
+			}
+			return setRedirectMode(domainID, mode)
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&mode, "mode", "none", "Redirect mode (none/www/naked)")
+
+	cmd.AddCommand(newDomainRedirectModeGetCmd())
+	cmd.AddCommand(newDomainRedirectModeSetCmd())
+
+	return cmd
+}
+
+func newDomainRedirectModeGetCmd() *cobra.Command {
+	var domainID int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the current redirect mode",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/redirect-mode", domainID), map[string]interface{}{
-				"mode": mode,
-			})
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/redirect-mode", domainID))
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Redirect mode set to: %s\n", mode)
+			// The mode comes either bare or wrapped in an object
+			var result struct {
+				Mode string `json:"mode"`
+			}
+			if err := json.Unmarshal(resp.Data, &result); err != nil {
+				if err := json.Unmarshal(resp.Data, &result.Mode); err != nil {
+					return fmt.Errorf("failed to parse redirect mode: %w", err)
+				}
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(result)
+			}
+
+			fmt.Printf("Redirect mode: %s\n", result.Mode)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func newDomainRedirectModeSetCmd() *cobra.Command {
+	var domainID int
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the redirect mode",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setRedirectMode(domainID, mode)
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&mode, "mode", "none", "Redirect mode (none/www/naked)")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("mode")
 
 	return cmd
 }
+
+func setRedirectMode(domainID int, mode string) error {
+	switch mode {
+	case "none", "www", "naked":
+	default:
+		return fmt.Errorf("invalid --mode %q: must be none, www or naked", mode)
+	}
+
+	client := api.NewClient()
+	_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/redirect-mode", domainID), map[string]interface{}{
+		"mode": mode,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Redirect mode set to: %s\n", mode)
+	return nil
+}
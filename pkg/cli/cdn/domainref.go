@@ -0,0 +1,152 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/completion"
+)
+
+// domainIDs caches name lookups for the rest of the invocation
+var domainIDs map[string]int
+
+// domainFlag is a --domain value that accepts a numeric ID or a domain name.
+// Names are resolved to IDs before the command runs.
+type domainFlag struct {
+	id   *int
+	name string
+}
+
+func (f *domainFlag) String() string {
+	if f.name != "" {
+		return f.name
+	}
+	if *f.id == 0 {
+		return ""
+	}
+	return strconv.Itoa(*f.id)
+}
+
+func (f *domainFlag) Set(value string) error {
+	if id, err := strconv.Atoi(value); err == nil {
+		if id <= 0 {
+			return fmt.Errorf("must be a positive ID")
+		}
+		*f.id = id
+		f.name = ""
+		return nil
+	}
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("must be a domain ID or name")
+	}
+	f.name = value
+	return nil
+}
+
+func (f *domainFlag) Type() string {
+	return "domain"
+}
+
+// addDomainFlag registers --domain on cmd, storing the resolved domain ID
+// in id. A domain name is looked up just before the command runs.
+func addDomainFlag(cmd *cobra.Command, id *int) {
+	flag := &domainFlag{id: id}
+	cmd.Flags().Var(flag, "domain", "Domain ID or name")
+	cmd.RegisterFlagCompletionFunc("domain", completeDomains)
+
+	preRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if flag.name != "" {
+			resolved, err := resolveDomainName(api.NewClient(), flag.name)
+			if err != nil {
+				return err
+			}
+			*id = resolved
+		}
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		return nil
+	}
+}
+
+// resolveDomainArg turns a positional domain argument (ID or name) into an ID
+func resolveDomainArg(client *api.Client, arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		if id <= 0 {
+			return 0, fmt.Errorf("invalid domain %d: must be a positive ID", id)
+		}
+		return id, nil
+	}
+	return resolveDomainName(client, arg)
+}
+
+// resolveDomainName finds the ID of the domain called name, matching the
+// domain's Name or Domain field case-insensitively
+func resolveDomainName(client *api.Client, name string) (int, error) {
+	key := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	if id, ok := domainIDs[key]; ok {
+		return id, nil
+	}
+
+	domains, _, err := api.GetList[Domain](client, "/v1/cdn/ng/domains", api.PageOptions{All: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up domain %q: %w", name, err)
+	}
+
+	var matches []int
+	for _, d := range domains {
+		if strings.EqualFold(d.Name, key) || strings.EqualFold(d.Domain, key) {
+			matches = append(matches, d.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no domain named %q found", name)
+	case 1:
+		if domainIDs == nil {
+			domainIDs = make(map[string]int)
+		}
+		domainIDs[key] = matches[0]
+		return matches[0], nil
+	default:
+		sort.Ints(matches)
+		ids := make([]string, len(matches))
+		for i, id := range matches {
+			ids[i] = strconv.Itoa(id)
+		}
+		return 0, fmt.Errorf("domain name %q matches %d domains (IDs %s); use the numeric ID", name, len(matches), strings.Join(ids, ", "))
+	}
+}
+
+// completeDomains offers domain IDs, described by name, for shell completion
+func completeDomains(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries := completion.Cached("domains", func(ctx context.Context) ([]string, error) {
+		client := api.NewClient().WithContext(ctx)
+		domains, _, err := api.GetList[Domain](client, "/v1/cdn/ng/domains", api.PageOptions{All: true})
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]string, 0, len(domains))
+		for _, d := range domains {
+			entries = append(entries, fmt.Sprintf("%d\t%s", d.ID, d.Name))
+		}
+		return entries, nil
+	})
+	return entries, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDomainArg completes the single positional domain argument
+func completeDomainArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeDomains(cmd, args, toComplete)
+}
@@ -1,13 +1,21 @@
 package cdn
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 type FirewallRule struct {
@@ -35,6 +43,8 @@ func NewAccessRulesCmd() *cobra.Command {
 	cmd.AddCommand(newFirewallRemoveIPCmd())
 	cmd.AddCommand(newFirewallAddCountryCmd())
 	cmd.AddCommand(newFirewallRemoveCountryCmd())
+	cmd.AddCommand(newFirewallExportCmd())
+	cmd.AddCommand(newFirewallImportCmd())
 
 	return cmd
 }
@@ -48,14 +58,13 @@ func newFirewallStatusCmd() *cobra.Command {
 		Short: "Get firewall rules",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var configs FirewallConfigs
@@ -70,29 +79,31 @@ func newFirewallStatusCmd() *cobra.Command {
 			if len(configs.IPRules) == 0 {
 				fmt.Println("  (none)")
 			} else {
-				fmt.Printf("  %-8s %-20s %-12s\n", "ID", "IP/CIDR", "ACTION")
-				fmt.Printf("  %s\n", strings.Repeat("-", 45))
+				table := output.NewTable("ID", "IP/CIDR", "ACTION")
+				table.SetIndent("  ")
 				for _, r := range configs.IPRules {
-					fmt.Printf("  %-8d %-20s %-12s\n", r.ID, r.Value, r.Action)
+					table.AddRow(r.ID, r.Value, r.Action)
 				}
+				table.Render()
 			}
 
 			fmt.Printf("\nCountry Rules:\n")
 			if len(configs.CountryRules) == 0 {
 				fmt.Println("  (none)")
 			} else {
-				fmt.Printf("  %-8s %-10s %-12s\n", "ID", "COUNTRY", "ACTION")
-				fmt.Printf("  %s\n", strings.Repeat("-", 35))
+				table := output.NewTable("ID", "COUNTRY", "ACTION")
+				table.SetIndent("  ")
 				for _, r := range configs.CountryRules {
-					fmt.Printf("  %-8d %-10s %-12s\n", r.ID, r.Value, r.Action)
+					table.AddRow(r.ID, r.Value, r.Action)
 				}
+				table.Render()
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -112,8 +123,12 @@ func newFirewallAddIPCmd() *cobra.Command {
     - allow:     Allow requests from this IP (whitelist)
     - challenge: Show captcha challenge`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validate.IPOrCIDR(ip); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
 				"type":   "ip",
 				"ip":     ip,
 				"action": action,
@@ -122,12 +137,12 @@ func newFirewallAddIPCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("IP rule added: %s -> %s\n", ip, action)
+			output.Infof("IP rule added: %s -> %s\n", ip, action)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&ip, "ip", "", "IP address or CIDR range")
 	cmd.Flags().StringVar(&action, "action", "block", "Action (block/allow/challenge)")
 	cmd.MarkFlagRequired("domain")
@@ -144,8 +159,12 @@ func newFirewallRemoveIPCmd() *cobra.Command {
 		Use:   "remove-ip",
 		Short: "Remove IP rule",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validate.IPOrCIDR(ip); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
 				"type":   "ip",
 				"ip":     ip,
 				"action": "remove",
@@ -154,12 +173,12 @@ func newFirewallRemoveIPCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("IP rule removed: %s\n", ip)
+			output.Infof("IP rule removed: %s\n", ip)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&ip, "ip", "", "IP address")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("ip")
@@ -180,23 +199,30 @@ func newFirewallAddCountryCmd() *cobra.Command {
     - allow:     Allow requests from this country (whitelist)
     - challenge: Show captcha challenge`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
-				"type":    "country",
-				"country": country,
-				"action":  action,
-			})
+			codes, err := validate.CountryCodes(country)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Country rule added: %s -> %s\n", country, action)
+			client := api.NewClient()
+			for _, code := range codes {
+				_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+					"type":    "country",
+					"country": code,
+					"action":  action,
+				})
+				if err != nil {
+					return err
+				}
+
+				output.Infof("Country rule added: %s -> %s\n", code, action)
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
-	cmd.Flags().StringVar(&country, "country", "", "Country code (e.g., US, DE, IR)")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&country, "country", "", "ISO country code, or a comma-separated list (e.g., US,DE,IR)")
 	cmd.Flags().StringVar(&action, "action", "block", "Action (block/allow/challenge)")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("country")
@@ -212,25 +238,354 @@ func newFirewallRemoveCountryCmd() *cobra.Command {
 		Use:   "remove-country",
 		Short: "Remove country rule",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
-				"type":    "country",
-				"country": country,
-				"action":  "remove",
-			})
+			codes, err := validate.CountryCodes(country)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Country rule removed: %s\n", country)
+			client := api.NewClient()
+			for _, code := range codes {
+				_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+					"type":    "country",
+					"country": code,
+					"action":  "remove",
+				})
+				if err != nil {
+					return err
+				}
+
+				output.Infof("Country rule removed: %s\n", code)
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
-	cmd.Flags().StringVar(&country, "country", "", "Country code")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&country, "country", "", "ISO country code, or a comma-separated list")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("country")
 
 	return cmd
 }
+
+func newFirewallExportCmd() *cobra.Command {
+	var domainID int
+	var format, outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export IP and country rules",
+		Long:  "Export a domain's IP and country rules as JSON (default) or CSV, in a form access-rules import accepts.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "json" && format != "csv" {
+				return fmt.Errorf("invalid format: %s (valid: json, csv)", format)
+			}
+
+			client := api.NewClient()
+			configs, err := getFirewallConfigs(client, domainID)
+			if err != nil {
+				return err
+			}
+
+			var data []byte
+			if format == "json" {
+				data, err = json.MarshalIndent(configs, "", "  ")
+				if err != nil {
+					return err
+				}
+				data = append(data, '\n')
+			} else {
+				data, err = firewallRulesCSV(configs.rules())
+				if err != nil {
+					return err
+				}
+			}
+
+			if outputFile == "" {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+			if err := os.WriteFile(outputFile, data, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputFile, err)
+			}
+			fmt.Printf("%d access rules exported to %s\n", len(configs.rules()), outputFile)
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&format, "format", "json", "Output format (json/csv)")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the export to a file instead of stdout")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func newFirewallImportCmd() *cobra.Command {
+	var domainID int
+	var file string
+	var replace, force bool
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import IP and country rules from a file",
+		Long: `Create IP and country rules from a file written by access-rules export:
+either JSON ({"ip_rules": [...], "country_rules": [...]}) or a .csv file with
+a type,value,action header.
+
+By default the rules are added to the existing ones. With --replace, the
+domain's rules are made to match the file: rules already present are left
+alone, new or changed ones are applied, and rules not in the file are removed
+afterwards. --replace asks for confirmation before removing anything unless
+--force or --yes is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rules, err := readFirewallRulesFile(file)
+			if err != nil {
+				return err
+			}
+			if len(rules) == 0 {
+				return fmt.Errorf("no rules found in %s", file)
+			}
+			for i, r := range rules {
+				if err := validateFirewallRule(r); err != nil {
+					return fmt.Errorf("rule %d: %w", i+1, err)
+				}
+			}
+
+			client := api.NewClient()
+
+			add, remove := rules, []FirewallRule(nil)
+			if replace {
+				configs, err := getFirewallConfigs(client, domainID)
+				if err != nil {
+					return err
+				}
+				add, remove = diffFirewallRules(configs.rules(), rules)
+			}
+
+			dryRun := config.GetConfig().DryRun
+			if dryRun {
+				table := output.NewTable("CHANGE", "TYPE", "VALUE", "ACTION")
+				for _, r := range add {
+					table.AddRow("add", r.Type, r.Value, r.Action)
+				}
+				for _, r := range remove {
+					table.AddRow("remove", r.Type, r.Value, r.Action)
+				}
+				table.Render()
+				output.Infof("\n[dry-run] %d rules would be added and %d removed\n", len(add), len(remove))
+				return nil
+			}
+
+			if len(remove) > 0 && !force {
+				ok, err := prompt.Confirm(fmt.Sprintf("Remove %d existing rules that are not in %s?", len(remove), file))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
+				}
+			}
+
+			applied, failed := 0, 0
+			progress := output.NewProgress(len(add))
+			for _, r := range add {
+				progress.Step(fmt.Sprintf("%s %s", r.Type, r.Value))
+				_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), r.body())
+				progress.Clear()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s %s: %v\n", r.Type, r.Value, err)
+					failed++
+					continue
+				}
+				applied++
+			}
+
+			output.Infof("Applied %d of %d rules", applied, len(add))
+			if failed > 0 {
+				// Keep the old rules rather than leave the domain with fewer
+				// rules than either the file or what it had before
+				output.Infof(" (%d failed)\n", failed)
+				if len(remove) > 0 {
+					return fmt.Errorf("%d rules could not be applied; no existing rules were removed", failed)
+				}
+				return fmt.Errorf("%d rules could not be applied", failed)
+			}
+			output.Infoln()
+
+			for i, r := range remove {
+				r.Action = "remove"
+				if _, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), r.body()); err != nil {
+					return fmt.Errorf("failed to remove %s rule %s after removing %d of %d: %w", r.Type, r.Value, i, len(remove), err)
+				}
+			}
+			if replace {
+				output.Infof("Removed %d rules not in %s\n", len(remove), file)
+			}
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&file, "file", "", "JSON or CSV file of rules")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Make the domain's rules match the file, removing rules not in it")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation for --replace")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// diffFirewallRules compares the domain's rules with the desired ones. add
+// holds desired rules that are missing or have a different action; remove
+// holds existing rules whose type and value aren't desired at all. Values
+// are compared case-insensitively.
+func diffFirewallRules(existing, desired []FirewallRule) (add, remove []FirewallRule) {
+	key := func(r FirewallRule) string {
+		return r.Type + "\x00" + strings.ToLower(r.Value)
+	}
+	current := map[string]FirewallRule{}
+	for _, r := range existing {
+		current[key(r)] = r
+	}
+	wanted := map[string]bool{}
+	for _, r := range desired {
+		wanted[key(r)] = true
+		if old, ok := current[key(r)]; ok && old.Action == r.Action {
+			continue
+		}
+		add = append(add, r)
+	}
+	for _, r := range existing {
+		if !wanted[key(r)] {
+			remove = append(remove, r)
+		}
+	}
+	return add, remove
+}
+
+func getFirewallConfigs(client *api.Client, domainID int) (*FirewallConfigs, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID))
+	if err != nil {
+		return nil, err
+	}
+
+	var configs FirewallConfigs
+	if err := json.Unmarshal(resp.Data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse configs: %w", err)
+	}
+	for i := range configs.IPRules {
+		configs.IPRules[i].Type = "ip"
+	}
+	for i := range configs.CountryRules {
+		configs.CountryRules[i].Type = "country"
+	}
+	return &configs, nil
+}
+
+// rules flattens the IP and country rules into one list, with Type set
+func (c *FirewallConfigs) rules() []FirewallRule {
+	var rules []FirewallRule
+	for _, r := range c.IPRules {
+		r.Type = "ip"
+		rules = append(rules, r)
+	}
+	for _, r := range c.CountryRules {
+		r.Type = "country"
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// body is the request that applies the rule, as sent by add-ip and add-country
+func (r FirewallRule) body() map[string]interface{} {
+	return map[string]interface{}{
+		"type":   r.Type,
+		r.Type:   r.Value,
+		"action": r.Action,
+	}
+}
+
+func validateFirewallRule(r FirewallRule) error {
+	if r.Type != "ip" && r.Type != "country" {
+		return fmt.Errorf("invalid type %q (valid: ip, country)", r.Type)
+	}
+	if r.Value == "" {
+		return fmt.Errorf("missing value")
+	}
+	if r.Type == "ip" {
+		if err := validate.IPOrCIDR(r.Value); err != nil {
+			return err
+		}
+	} else if _, err := validate.CountryCodes(r.Value); err != nil {
+		return err
+	}
+	switch r.Action {
+	case "block", "allow", "challenge":
+	default:
+		return fmt.Errorf("invalid action %q for %s (valid: block, allow, challenge)", r.Action, r.Value)
+	}
+	return nil
+}
+
+// firewallRulesCSV renders rules as CSV with a type,value,action header
+func firewallRulesCSV(rules []FirewallRule) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"type", "value", "action"})
+	for _, r := range rules {
+		w.Write([]string{r.Type, r.Value, r.Action})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// readFirewallRulesFile loads rules from the JSON or CSV written by export
+func readFirewallRulesFile(path string) ([]FirewallRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		var configs FirewallConfigs
+		if err := json.Unmarshal(data, &configs); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as JSON rules: %w", path, err)
+		}
+		return configs.rules(), nil
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"type", "value", "action"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the %q column", required)
+		}
+	}
+
+	var rules []FirewallRule
+	for _, row := range rows[1:] {
+		field := func(name string) string {
+			if i := columns[name]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		rules = append(rules, FirewallRule{
+			Type:   strings.ToLower(field("type")),
+			Value:  field("value"),
+			Action: strings.ToLower(field("action")),
+		})
+	}
+	return rules, nil
+}
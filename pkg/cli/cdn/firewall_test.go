@@ -0,0 +1,59 @@
+package cdn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFirewallRules(t *testing.T) {
+	existing := []FirewallRule{
+		{ID: 1, Type: "ip", Value: "203.0.113.7", Action: "block"},
+		{ID: 2, Type: "ip", Value: "198.51.100.0/24", Action: "allow"},
+		{ID: 3, Type: "country", Value: "CN", Action: "challenge"},
+	}
+
+	tests := []struct {
+		name       string
+		desired    []FirewallRule
+		wantAdd    []FirewallRule
+		wantRemove []FirewallRule
+	}{
+		{
+			name:    "identical",
+			desired: []FirewallRule{{Type: "ip", Value: "203.0.113.7", Action: "block"}, {Type: "ip", Value: "198.51.100.0/24", Action: "allow"}, {Type: "country", Value: "CN", Action: "challenge"}},
+		},
+		{
+			name:       "new rule and one dropped",
+			desired:    []FirewallRule{{Type: "ip", Value: "203.0.113.7", Action: "block"}, {Type: "country", Value: "CN", Action: "challenge"}, {Type: "ip", Value: "192.0.2.1", Action: "block"}},
+			wantAdd:    []FirewallRule{{Type: "ip", Value: "192.0.2.1", Action: "block"}},
+			wantRemove: []FirewallRule{existing[1]},
+		},
+		{
+			name:    "changed action is re-applied, not removed",
+			desired: []FirewallRule{{Type: "ip", Value: "203.0.113.7", Action: "allow"}, {Type: "ip", Value: "198.51.100.0/24", Action: "allow"}, {Type: "country", Value: "CN", Action: "challenge"}},
+			wantAdd: []FirewallRule{{Type: "ip", Value: "203.0.113.7", Action: "allow"}},
+		},
+		{
+			name:    "country codes compare case-insensitively",
+			desired: []FirewallRule{{Type: "ip", Value: "203.0.113.7", Action: "block"}, {Type: "ip", Value: "198.51.100.0/24", Action: "allow"}, {Type: "country", Value: "cn", Action: "challenge"}},
+		},
+		{
+			name:       "same value, other type",
+			desired:    []FirewallRule{{Type: "country", Value: "203.0.113.7", Action: "block"}},
+			wantAdd:    []FirewallRule{{Type: "country", Value: "203.0.113.7", Action: "block"}},
+			wantRemove: existing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := diffFirewallRules(existing, tt.desired)
+			if !reflect.DeepEqual(add, tt.wantAdd) {
+				t.Errorf("add = %v, want %v", add, tt.wantAdd)
+			}
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("remove = %v, want %v", remove, tt.wantRemove)
+			}
+		})
+	}
+}
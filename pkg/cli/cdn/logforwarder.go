@@ -3,12 +3,16 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
 	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type LogForwarder struct {
@@ -18,6 +22,16 @@ type LogForwarder struct {
 	Endpoint    string            `json:"endpoint"`
 	Enabled     types.NumericBool `json:"enabled"`
 	CreatedAt   string            `json:"created_at"`
+
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// LogForwarderTestResult is the outcome of sending a test log line
+type LogForwarderTestResult struct {
+	Delivered  bool   `json:"delivered"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMS  int    `json:"latency_ms,omitempty"`
 }
 
 func NewLogForwarderCmd() *cobra.Command {
@@ -29,9 +43,11 @@ func NewLogForwarderCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newLogForwarderListCmd())
+	cmd.AddCommand(newLogForwarderGetCmd())
 	cmd.AddCommand(newLogForwarderAddCmd())
 	cmd.AddCommand(newLogForwarderUpdateCmd())
 	cmd.AddCommand(newLogForwarderDeleteCmd())
+	cmd.AddCommand(newLogForwarderTestCmd())
 
 	return cmd
 }
@@ -45,14 +61,13 @@ func newLogForwarderListCmd() *cobra.Command {
 		Short: "List log forwarders",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/log-forwarders", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/log-forwarders", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var forwarders []LogForwarder
@@ -65,33 +80,150 @@ func newLogForwarderListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-20s %-15s %-35s %-8s\n", "ID", "NAME", "TYPE", "ENDPOINT", "ENABLED")
-			fmt.Println(strings.Repeat("-", 90))
+			table := output.NewTable("ID", "NAME", "TYPE", "ENDPOINT", "ENABLED")
 			for _, f := range forwarders {
-				enabled := "No"
-				if f.Enabled.Bool() {
-					enabled = "Yes"
-				}
-				fmt.Printf("%-6d %-20s %-15s %-35s %-8s\n",
-					f.ID, truncate(f.Name, 20), f.Type, truncate(f.Endpoint, 35), enabled)
+				table.AddRow(f.ID, f.Name, f.Type, f.Endpoint, f.Enabled)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
+func newLogForwarderGetCmd() *cobra.Command {
+	var domainID, forwarderID int
+	var jsonOutput, showSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show a log forwarder's full configuration",
+		Long:  "Show a log forwarder including the settings passed with --settings. Passwords, tokens and keys are masked unless --show-secrets is given.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/log-forwarders/%d", domainID, forwarderID))
+			if api.IsNotFound(err) {
+				return fmt.Errorf("log forwarder %d not found on domain %d", forwarderID, domainID)
+			}
+			if err != nil {
+				return err
+			}
+
+			var forwarder LogForwarder
+			if err := json.Unmarshal(resp.Data, &forwarder); err != nil {
+				return fmt.Errorf("failed to parse forwarder: %w", err)
+			}
+			if !showSecrets {
+				maskForwarderSecrets(forwarder.Config)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(forwarder)
+			}
+
+			fmt.Printf("ID:       %d\n", forwarder.ID)
+			fmt.Printf("Name:     %s\n", forwarder.Name)
+			fmt.Printf("Type:     %s\n", forwarder.Type)
+			fmt.Printf("Endpoint: %s\n", forwarder.Endpoint)
+			fmt.Printf("Enabled:  %s\n", forwarder.Enabled)
+			if forwarder.CreatedAt != "" {
+				fmt.Printf("Created:  %s\n", forwarder.CreatedAt)
+			}
+
+			if len(forwarder.Config) == 0 {
+				fmt.Println("\nNo additional config")
+				return nil
+			}
+
+			keys := make([]string, 0, len(forwarder.Config))
+			for key := range forwarder.Config {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			fmt.Println("\nConfig:")
+			table := output.NewTable("KEY", "VALUE")
+			table.SetIndent("  ")
+			for _, key := range keys {
+				table.AddRow(key, formatConfigValue(forwarder.Config[key]))
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&forwarderID, "forwarder", 0, "Forwarder ID")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show passwords, tokens and keys unmasked")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("forwarder")
+
+	return cmd
+}
+
+// secretConfigKeys are substrings of forwarder config keys holding credentials
+var secretConfigKeys = []string{"password", "secret", "token", "api_key", "apikey", "access_key", "private_key", "credential"}
+
+// maskForwarderSecrets masks credential values in place, including nested objects
+func maskForwarderSecrets(config map[string]interface{}) {
+	for key, value := range config {
+		switch v := value.(type) {
+		case string:
+			if isSecretConfigKey(key) {
+				config[key] = util.MaskSecret(v)
+			}
+		case map[string]interface{}:
+			if isSecretConfigKey(key) {
+				config[key] = "********"
+			} else {
+				maskForwarderSecrets(v)
+			}
+		default:
+			if isSecretConfigKey(key) && v != nil {
+				config[key] = "********"
+			}
+		}
+	}
+}
+
+func isSecretConfigKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, s := range secretConfigKeys {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatConfigValue shows scalars as-is and objects or lists as compact JSON
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "-"
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 func newLogForwarderAddCmd() *cobra.Command {
 	var domainID int
 	var name, forwarderType, endpoint string
 	var enabled bool
-	var config string
+	var settings string
 
 	cmd := &cobra.Command{
 		Use:   "add",
@@ -109,41 +241,45 @@ func newLogForwarderAddCmd() *cobra.Command {
 				"enabled":  enabled,
 			}
 
-			if config != "" {
+			if settings != "" {
 				var configMap map[string]interface{}
-				if err := json.Unmarshal([]byte(config), &configMap); err != nil {
-					return fmt.Errorf("invalid config JSON: %w", err)
+				if err := json.Unmarshal([]byte(settings), &configMap); err != nil {
+					return fmt.Errorf("invalid --settings JSON: %w", err)
 				}
 				body["config"] = configMap
 			}
 
 			client := api.NewClient()
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/log-forwarders", domainID), body)
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/log-forwarders", domainID), body)
 			if err != nil {
 				return err
 			}
 
 			var forwarder LogForwarder
 			if err := json.Unmarshal(resp.Data, &forwarder); err != nil {
-				fmt.Println("Log forwarder added successfully")
+				output.Infoln("Log forwarder added successfully")
 				return nil
 			}
 
-			fmt.Printf("Log forwarder added successfully!\n")
-			fmt.Printf("ID: %d\n", forwarder.ID)
-			fmt.Printf("Name: %s\n", forwarder.Name)
-			fmt.Printf("Type: %s\n", forwarder.Type)
+			output.Infof("Log forwarder added successfully!\n")
+			output.Infof("ID: %d\n", forwarder.ID)
+			output.PrintID(forwarder.ID)
+			output.Infof("Name: %s\n", forwarder.Name)
+			output.Infof("Type: %s\n", forwarder.Type)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&name, "name", "", "Forwarder name")
 	cmd.Flags().StringVar(&forwarderType, "type", "", "Forwarder type (elasticsearch/s3/http/datadog)")
 	cmd.Flags().StringVar(&endpoint, "endpoint", "", "Destination endpoint URL")
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable forwarder")
-	cmd.Flags().StringVar(&config, "config", "", "Additional config as JSON")
+	cmd.Flags().StringVar(&settings, "settings", "", "Additional type-specific settings as JSON")
+	// --config was the original name, but it hides the global --config
+	cmd.Flags().StringVar(&settings, "config", "", "Additional type-specific settings as JSON")
+	cmd.Flags().MarkDeprecated("config", "use --settings instead")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("type")
@@ -171,17 +307,17 @@ func newLogForwarderUpdateCmd() *cobra.Command {
 			body["enabled"] = enabled
 
 			client := api.NewClient()
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/log-forwarders/%d", domainID, forwarderID), body)
+			_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/log-forwarders/%d", domainID, forwarderID), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Log forwarder updated successfully")
+			output.Infoln("Log forwarder updated successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&forwarderID, "forwarder", 0, "Forwarder ID")
 	cmd.Flags().StringVar(&name, "name", "", "Forwarder name")
 	cmd.Flags().StringVar(&endpoint, "endpoint", "", "Destination endpoint URL")
@@ -202,29 +338,91 @@ func newLogForwarderDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete forwarder %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete forwarder %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/log-forwarders/%s", domainID, args[0]))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/log-forwarders/%s", domainID, args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("Log forwarder already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Log forwarder deleted successfully")
+			output.Infoln("Log forwarder deleted successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
+
+func newLogForwarderTestCmd() *cobra.Command {
+	var domainID, forwarderID int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Send a test log line through a forwarder",
+		Long:  "Ask the CDN to send a synthetic log line to the forwarder's destination and report whether it was accepted. Exits non-zero if delivery fails.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/log-forwarders/%d/test", domainID, forwarderID), map[string]interface{}{})
+			if err != nil {
+				return err
+			}
+
+			var result LogForwarderTestResult
+			if err := json.Unmarshal(resp.Data, &result); err != nil {
+				return fmt.Errorf("failed to parse test result: %w", err)
+			}
+			if result.Message == "" {
+				result.Message = resp.Message
+			}
+
+			if jsonOutput {
+				if err := output.PrintJSONValue(result); err != nil {
+					return err
+				}
+			} else if result.Delivered {
+				fmt.Printf("Test log line delivered to forwarder %d", forwarderID)
+				if result.LatencyMS > 0 {
+					fmt.Printf(" in %dms", result.LatencyMS)
+				}
+				fmt.Println()
+			}
+
+			if !result.Delivered {
+				msg := result.Message
+				if msg == "" {
+					msg = "the destination did not accept the log line"
+				}
+				if result.StatusCode > 0 {
+					msg = fmt.Sprintf("%s (destination returned HTTP %d)", msg, result.StatusCode)
+				}
+				return fmt.Errorf("log forwarder %d test failed: %s", forwarderID, msg)
+			}
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&forwarderID, "forwarder", 0, "Forwarder ID")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("forwarder")
+
+	return cmd
+}
@@ -0,0 +1,182 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+)
+
+// DomainOverview gathers the settings cdn overview reports. A section is
+// nil when it couldn't be fetched; Errors says why.
+type DomainOverview struct {
+	Domain    *Domain            `json:"domain"`
+	Cache     *CacheSettings     `json:"cache"`
+	SSL       *SSLInfo           `json:"ssl"`
+	WAF       *WAFStatus         `json:"waf"`
+	DDoS      *DDoSSettings      `json:"ddos"`
+	RateLimit *RateLimitSettings `json:"ratelimit"`
+	Errors    map[string]string  `json:"errors,omitempty"`
+}
+
+func NewCDNCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cdn",
+		Short: "Summarize a domain's CDN settings",
+		Long:  "Views that combine several CDN settings at once. Use the domain, cache, ssl, waf, ddos and ratelimit commands to change them.",
+	}
+
+	cmd.AddCommand(newCDNOverviewCmd())
+
+	return cmd
+}
+
+func newCDNOverviewCmd() *cobra.Command {
+	var domainID int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "overview",
+		Short: "Show a one-screen summary of a domain's CDN posture",
+		Long: `Fetch the domain's cache, SSL, WAF, DDoS and rate limit settings at the same
+time and print one line for each. Sections that can't be fetched are marked
+unavailable rather than failing the whole command; it only fails when
+nothing could be fetched.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			overview := getDomainOverview(api.NewClient(), domainID)
+			if overview.empty() {
+				return fmt.Errorf("failed to fetch any settings for domain %d: %s", domainID, overview.Errors["domain"])
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(overview)
+			}
+			printDomainOverview(overview, domainID)
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+// getDomainOverview fetches every section concurrently. The client's
+// context bounds them all, so --timeout and Ctrl-C stop the lot.
+func getDomainOverview(client *api.Client, domainID int) *DomainOverview {
+	overview := &DomainOverview{
+		Domain:    &Domain{},
+		Cache:     &CacheSettings{},
+		SSL:       &SSLInfo{},
+		WAF:       &WAFStatus{},
+		DDoS:      &DDoSSettings{},
+		RateLimit: &RateLimitSettings{},
+	}
+	sections := []struct {
+		name, path string
+		target     interface{}
+		clear      func()
+	}{
+		{"domain", "/v1/cdn/ng/domains/%d", overview.Domain, func() { overview.Domain = nil }},
+		{"cache", "/v1/cdn/ng/domains/%d/cache", overview.Cache, func() { overview.Cache = nil }},
+		{"ssl", "/v1/cdn/ng/domains/%d/https/ssl/get-info", overview.SSL, func() { overview.SSL = nil }},
+		{"waf", "/v1/cdn/ng/domains/%d/waf", overview.WAF, func() { overview.WAF = nil }},
+		{"ddos", "/v1/cdn/ng/domains/%d/ddos", overview.DDoS, func() { overview.DDoS = nil }},
+		{"ratelimit", "/v1/cdn/ng/domains/%d/ratelimit", overview.RateLimit, func() { overview.RateLimit = nil }},
+	}
+
+	endpoints := make([]string, len(sections))
+	for i, s := range sections {
+		endpoints[i] = api.Endpoint(s.path, domainID)
+	}
+	// Failures are reported per section below, so the joined error isn't needed
+	results, _ := client.ParallelGet(endpoints, len(endpoints))
+
+	for i, s := range sections {
+		err := results[i].Err
+		if err == nil {
+			if jsonErr := json.Unmarshal(results[i].Response.Data, s.target); jsonErr != nil {
+				err = fmt.Errorf("failed to parse response: %w", jsonErr)
+			}
+		}
+		if err != nil {
+			if overview.Errors == nil {
+				overview.Errors = map[string]string{}
+			}
+			overview.Errors[s.name] = err.Error()
+			s.clear()
+		}
+	}
+	return overview
+}
+
+// empty reports whether every section failed
+func (o *DomainOverview) empty() bool {
+	return o.Domain == nil && o.Cache == nil && o.SSL == nil && o.WAF == nil && o.DDoS == nil && o.RateLimit == nil
+}
+
+func printDomainOverview(o *DomainOverview, domainID int) {
+	title := fmt.Sprintf("Domain %d", domainID)
+	if o.Domain != nil {
+		title = fmt.Sprintf("%s (ID %d, %s)", o.Domain.displayName(), domainID, o.Domain.Status)
+	}
+	fmt.Println(title)
+	fmt.Println(strings.Repeat("=", len(title)))
+
+	line := func(label, section string, ok bool, summary func() string) {
+		value := "unavailable"
+		if ok {
+			value = summary()
+		} else if err, failed := o.Errors[section]; failed {
+			value = "unavailable: " + err
+		}
+		fmt.Printf("%-12s %s\n", label+":", value)
+	}
+
+	line("Cache", "cache", o.Cache != nil, func() string {
+		return fmt.Sprintf("%s, TTL %ds, developer mode %s", o.Cache.CacheMode, o.Cache.CacheTTL, o.Cache.DeveloperMode)
+	})
+	line("SSL", "ssl", o.SSL != nil, func() string {
+		if !o.SSL.HasSSL {
+			return "no certificate"
+		}
+		summary := o.SSL.Issuer
+		if summary == "" {
+			summary = "unknown issuer"
+		}
+		switch {
+		case !o.SSL.ValidTo.IsZero():
+			days := int(math.Floor(time.Until(o.SSL.ValidTo.Time()).Hours() / 24))
+			if days < 0 {
+				return fmt.Sprintf("%s, EXPIRED %s", summary, o.SSL.ValidTo.Time().Format("2006-01-02"))
+			}
+			return fmt.Sprintf("%s, expires %s (%d days)", summary, o.SSL.ValidTo.Time().Format("2006-01-02"), days)
+		case o.SSL.ValidTo.String() != "":
+			return fmt.Sprintf("%s, expires %s", summary, o.SSL.ValidTo)
+		}
+		return summary
+	})
+	line("WAF", "waf", o.WAF != nil, func() string {
+		if !o.WAF.Enabled {
+			return "off"
+		}
+		return fmt.Sprintf("on (%s)", o.WAF.Mode)
+	})
+	line("DDoS", "ddos", o.DDoS != nil, func() string {
+		return fmt.Sprintf("%s, under attack %s", o.DDoS.Mode, o.DDoS.UnderAttack)
+	})
+	line("Rate limit", "ratelimit", o.RateLimit != nil, func() string {
+		if !o.RateLimit.Enabled {
+			return "off"
+		}
+		return fmt.Sprintf("on (%d req/s, block %ds)", o.RateLimit.Limit, o.RateLimit.Block)
+	})
+}
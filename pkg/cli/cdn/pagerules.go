@@ -3,11 +3,16 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 type PageRulePath struct {
@@ -23,6 +28,13 @@ type PageRule struct {
 	Settings interface{} `json:"settings"`
 }
 
+// pageRuleTypes are the rule types a path can carry
+var pageRuleTypes = []string{"cache", "waf", "ratelimit", "ddos", "firewall"}
+
+func validatePageRuleType(ruleType string) error {
+	return validateChoice("type", ruleType, pageRuleTypes...)
+}
+
 func NewPageRulesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "page-rules",
@@ -34,6 +46,7 @@ func NewPageRulesCmd() *cobra.Command {
 	cmd.AddCommand(newPageRulesListCmd())
 	cmd.AddCommand(newPageRulesAddPathCmd())
 	cmd.AddCommand(newPageRulesDeletePathCmd())
+	cmd.AddCommand(newPageRulesGetRuleCmd())
 	cmd.AddCommand(newPageRulesSetCmd())
 	cmd.AddCommand(newPageRulesDeleteRuleCmd())
 
@@ -57,9 +70,9 @@ func newPageRulesListCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
 
-			endpoint := fmt.Sprintf("/v1/cdn/ng/domains/%d/paths", domainID)
+			endpoint := api.Endpoint("/v1/cdn/ng/domains/%d/paths", domainID)
 			if ruleType != "" && ruleType != "all" {
-				endpoint = fmt.Sprintf("/v1/cdn/ng/domains/%d/paths/%s", domainID, ruleType)
+				endpoint = api.Endpoint("/v1/cdn/ng/domains/%d/paths/%s", domainID, ruleType)
 			}
 
 			resp, err := client.Get(endpoint)
@@ -68,8 +81,7 @@ func newPageRulesListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var paths []PageRulePath
@@ -82,17 +94,17 @@ func newPageRulesListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-8s %-40s %-10s\n", "ID", "PATH", "PRIORITY")
-			fmt.Println(strings.Repeat("-", 60))
+			table := output.NewTable("ID", "PATH", "PRIORITY")
 			for _, p := range paths {
-				fmt.Printf("%-8d %-40s %-10d\n", p.ID, truncate(p.Path, 40), p.Priority)
+				table.AddRow(p.ID, p.Path, p.Priority)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().StringVar(&ruleType, "type", "all", "Rule type (all/waf/ratelimit/ddos/firewall)")
 	cmd.MarkFlagRequired("domain")
@@ -114,7 +126,7 @@ func newPageRulesAddPathCmd() *cobra.Command {
   - *.js        Matches any .js file`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/paths", domainID), map[string]interface{}{
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/paths", domainID), map[string]interface{}{
 				"path":     path,
 				"priority": priority,
 			})
@@ -124,20 +136,21 @@ func newPageRulesAddPathCmd() *cobra.Command {
 
 			var result PageRulePath
 			if err := json.Unmarshal(resp.Data, &result); err != nil {
-				fmt.Println("Path added successfully")
+				output.Infoln("Path added successfully")
 				return nil
 			}
 
-			fmt.Printf("Path added successfully!\n")
-			fmt.Printf("ID: %d\n", result.ID)
-			fmt.Printf("Path: %s\n", result.Path)
-			fmt.Printf("Priority: %d\n", result.Priority)
+			output.Infof("Path added successfully!\n")
+			output.Infof("ID: %d\n", result.ID)
+			output.PrintID(result.ID)
+			output.Infof("Path: %s\n", result.Path)
+			output.Infof("Priority: %d\n", result.Priority)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&path, "path", "", "URL path pattern")
 	cmd.Flags().IntVar(&priority, "priority", 10, "Rule priority (lower = higher priority)")
 	cmd.MarkFlagRequired("domain")
@@ -156,33 +169,115 @@ func newPageRulesDeletePathCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete path %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete path %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/paths/%s", domainID, args[0]))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/paths/%s", domainID, args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("Path already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Path deleted successfully")
+			output.Infoln("Path deleted successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
+func newPageRulesGetRuleCmd() *cobra.Command {
+	var domainID int
+	var pathID int
+	var ruleType string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "get-rule",
+		Short: "Show the settings of a path's rule",
+		Long:  "Show the settings currently attached to a path for one rule type. The --json output can be passed back to set-rule --settings.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validatePageRuleType(ruleType); err != nil {
+				return err
+			}
+
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/paths/%d/rules/%s", domainID, pathID, ruleType))
+			if api.IsNotFound(err) {
+				return fmt.Errorf("no %s rule set for path %d", ruleType, pathID)
+			}
+			if err != nil {
+				return err
+			}
+
+			var rule PageRule
+			if err := json.Unmarshal(resp.Data, &rule); err != nil {
+				return fmt.Errorf("failed to parse rule: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(rule.Settings)
+			}
+
+			fmt.Printf("Path:  %d\n", pathID)
+			fmt.Printf("Type:  %s\n", ruleType)
+			if rule.ID != 0 {
+				fmt.Printf("Rule:  %d\n", rule.ID)
+			}
+
+			settings, ok := rule.Settings.(map[string]interface{})
+			if !ok {
+				fmt.Printf("Settings: %s\n", formatConfigValue(rule.Settings))
+				return nil
+			}
+			if len(settings) == 0 {
+				fmt.Println("\nNo settings")
+				return nil
+			}
+
+			keys := make([]string, 0, len(settings))
+			for key := range settings {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			fmt.Println("\nSettings:")
+			table := output.NewTable("KEY", "VALUE")
+			table.SetIndent("  ")
+			for _, key := range keys {
+				table.AddRow(key, formatConfigValue(settings[key]))
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&pathID, "path", 0, "Path ID")
+	cmd.Flags().StringVar(&ruleType, "type", "", "Rule type (cache/waf/ratelimit/ddos/firewall)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the settings as JSON")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("path")
+	cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
 func newPageRulesSetCmd() *cobra.Command {
 	var domainID int
 	var pathID int
@@ -192,43 +287,241 @@ func newPageRulesSetCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set-rule",
 		Short: "Set a rule for a path",
-		Long: `Set a rule for a specific path. Rule types:
+		Long: `Set a rule for a specific path using one of the typed subcommands:
   - cache:     Cache settings
   - waf:       WAF settings
   - ratelimit: Rate limiting
   - ddos:      DDoS protection
   - firewall:  Firewall rules
 
-Settings should be JSON format.`,
+For settings the subcommands don't cover, pass raw JSON instead:
+  set-rule --domain <id> --path <path-id> --type cache --settings '{...}'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("type") {
+				return cmd.Help()
+			}
+			for _, name := range []string{"domain", "path"} {
+				if !cmd.Flags().Changed(name) {
+					return fmt.Errorf("required flag(s) %q not set", name)
+				}
+			}
+			if err := validatePageRuleType(ruleType); err != nil {
+				return err
+			}
+
 			var settingsMap map[string]interface{}
 			if err := json.Unmarshal([]byte(settings), &settingsMap); err != nil {
 				return fmt.Errorf("invalid settings JSON: %w", err)
 			}
 
-			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/paths/%d/rules", domainID, pathID), map[string]interface{}{
-				"type":     ruleType,
-				"settings": settingsMap,
+			return setPageRule(domainID, pathID, ruleType, settingsMap)
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&pathID, "path", 0, "Path ID")
+	cmd.Flags().StringVar(&ruleType, "type", "", "Rule type (cache/waf/ratelimit/ddos/firewall)")
+	cmd.Flags().StringVar(&settings, "settings", "{}", "Rule settings as JSON")
+
+	cmd.AddCommand(newPageRuleCacheCmd())
+	cmd.AddCommand(newPageRuleWAFCmd())
+	cmd.AddCommand(newPageRuleRateLimitCmd())
+	cmd.AddCommand(newPageRuleDDoSCmd())
+	cmd.AddCommand(newPageRuleFirewallCmd())
+
+	return cmd
+}
+
+func newPageRuleCacheCmd() *cobra.Command {
+	var domainID, pathID int
+	var mode string
+	var ttl int
+	var ignoreQuery bool
+
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Set the cache rule for a path",
+		Long: `Set how responses under a path are cached at the edge:
+  --mode: standard, aggressive (cache more content) or no-cache
+  --ttl:  Edge cache lifetime in seconds`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateChoice("mode", mode, "standard", "aggressive", "no-cache"); err != nil {
+				return err
+			}
+			if ttl < 0 {
+				return fmt.Errorf("invalid --ttl %d: must be 0 or more seconds", ttl)
+			}
+
+			return setPageRule(domainID, pathID, "cache", map[string]interface{}{
+				"mode":         mode,
+				"ttl":          ttl,
+				"ignore_query": ignoreQuery,
 			})
-			if err != nil {
+		},
+	}
+
+	addPageRuleFlags(cmd, &domainID, &pathID)
+	cmd.Flags().StringVar(&mode, "mode", "standard", "Cache mode (standard/aggressive/no-cache)")
+	cmd.Flags().IntVar(&ttl, "ttl", 3600, "Cache TTL in seconds")
+	cmd.Flags().BoolVar(&ignoreQuery, "ignore-query", false, "Ignore the query string in the cache key")
+
+	return cmd
+}
+
+func newPageRuleWAFCmd() *cobra.Command {
+	var domainID, pathID int
+	var enabled bool
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "waf",
+		Short: "Set the WAF rule for a path",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateChoice("mode", mode, "block", "simulate"); err != nil {
 				return err
 			}
 
-			fmt.Printf("Rule '%s' set for path %d successfully\n", ruleType, pathID)
-			return nil
+			return setPageRule(domainID, pathID, "waf", map[string]interface{}{
+				"enabled": enabled,
+				"mode":    mode,
+			})
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
-	cmd.Flags().IntVar(&pathID, "path", 0, "Path ID")
-	cmd.Flags().StringVar(&ruleType, "type", "", "Rule type (cache/waf/ratelimit/ddos/firewall)")
-	cmd.Flags().StringVar(&settings, "settings", "{}", "Rule settings as JSON")
+	addPageRuleFlags(cmd, &domainID, &pathID)
+	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable/disable the WAF for this path")
+	cmd.Flags().StringVar(&mode, "mode", "block", "WAF mode (block/simulate)")
+
+	return cmd
+}
+
+func newPageRuleRateLimitCmd() *cobra.Command {
+	var domainID, pathID int
+	var enabled bool
+	var limit, block int
+
+	cmd := &cobra.Command{
+		Use:   "ratelimit",
+		Short: "Set the rate limit rule for a path",
+		Long: `Set rate limiting for a path:
+  --limit: Max requests per second (1-1000)
+  --block: Block duration in seconds (1-1000)`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 1 || limit > 1000 {
+				return fmt.Errorf("invalid --limit %d: must be between 1 and 1000 requests per second", limit)
+			}
+			if block < 1 || block > 1000 {
+				return fmt.Errorf("invalid --block %d: must be between 1 and 1000 seconds", block)
+			}
+
+			return setPageRule(domainID, pathID, "ratelimit", map[string]interface{}{
+				"mode":          enabled,
+				"request_count": limit,
+				"block_time":    block,
+			})
+		},
+	}
+
+	addPageRuleFlags(cmd, &domainID, &pathID)
+	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable/disable rate limiting for this path")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Max requests per second (1-1000)")
+	cmd.Flags().IntVar(&block, "block", 60, "Block duration in seconds (1-1000)")
+
+	return cmd
+}
+
+func newPageRuleDDoSCmd() *cobra.Command {
+	var domainID, pathID int
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "ddos",
+		Short: "Set the DDoS protection rule for a path",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateChoice("mode", mode, "off", "normal", "high", "under_attack"); err != nil {
+				return err
+			}
+
+			return setPageRule(domainID, pathID, "ddos", map[string]interface{}{
+				"mode": mode,
+			})
+		},
+	}
+
+	addPageRuleFlags(cmd, &domainID, &pathID)
+	cmd.Flags().StringVar(&mode, "mode", "normal", "Protection mode (off/normal/high/under_attack)")
+	cmd.MarkFlagRequired("mode")
+
+	return cmd
+}
+
+func newPageRuleFirewallCmd() *cobra.Command {
+	var domainID, pathID int
+	var action string
+	var ips, countries []string
+
+	cmd := &cobra.Command{
+		Use:   "firewall",
+		Short: "Set the firewall rule for a path",
+		Long:  "Apply an action to requests for a path from the given IPs and/or countries.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateChoice("action", action, "block", "allow", "challenge"); err != nil {
+				return err
+			}
+			if err := validate.IPOrCIDRList(ips); err != nil {
+				return fmt.Errorf("invalid --ips: %w", err)
+			}
+			codes, err := validate.CountryCodes(countries...)
+			if err != nil {
+				return fmt.Errorf("invalid --countries: %w", err)
+			}
+
+			return setPageRule(domainID, pathID, "firewall", map[string]interface{}{
+				"action":    action,
+				"ips":       ips,
+				"countries": append([]string{}, codes...),
+			})
+		},
+	}
+
+	addPageRuleFlags(cmd, &domainID, &pathID)
+	cmd.Flags().StringVar(&action, "action", "block", "Action (block/allow/challenge)")
+	cmd.Flags().StringSliceVar(&ips, "ips", []string{}, "IP addresses or CIDR ranges (comma-separated)")
+	cmd.Flags().StringSliceVar(&countries, "countries", []string{}, "Country codes (comma-separated, e.g., US,DE)")
+	cmd.MarkFlagsOneRequired("ips", "countries")
+
+	return cmd
+}
+
+// addPageRuleFlags adds the --domain and --path flags every set-rule
+// subcommand needs
+func addPageRuleFlags(cmd *cobra.Command, domainID, pathID *int) {
+	addDomainFlag(cmd, domainID)
+	cmd.Flags().IntVar(pathID, "path", 0, "Path ID")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("path")
-	cmd.MarkFlagRequired("type")
+}
 
-	return cmd
+// validateChoice checks that a flag's value is one of the allowed values
+func validateChoice(flag, value string, allowed ...string) error {
+	if !slices.Contains(allowed, value) {
+		return fmt.Errorf("invalid --%s %q: must be one of %s", flag, value, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+func setPageRule(domainID, pathID int, ruleType string, settings map[string]interface{}) error {
+	client := api.NewClient()
+	_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/paths/%d/rules", domainID, pathID), map[string]interface{}{
+		"type":     ruleType,
+		"settings": settings,
+	})
+	if err != nil {
+		return err
+	}
+
+	output.Infof("Rule '%s' set for path %d successfully\n", ruleType, pathID)
+	return nil
 }
 
 func newPageRulesDeleteRuleCmd() *cobra.Command {
@@ -241,17 +534,17 @@ func newPageRulesDeleteRuleCmd() *cobra.Command {
 		Short: "Delete a rule from a path",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/paths/%d/rules/%s", domainID, pathID, ruleType))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/paths/%d/rules/%s", domainID, pathID, ruleType))
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Rule '%s' deleted from path %d\n", ruleType, pathID)
+			output.Infof("Rule '%s' deleted from path %d\n", ruleType, pathID)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&pathID, "path", 0, "Path ID")
 	cmd.Flags().StringVar(&ruleType, "type", "", "Rule type")
 	cmd.MarkFlagRequired("domain")
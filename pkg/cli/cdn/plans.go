@@ -3,11 +3,12 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type CDNPlan struct {
@@ -33,7 +34,7 @@ func NewPlansCmd() *cobra.Command {
 }
 
 func newPlansListCmd() *cobra.Command {
-	var jsonOutput bool
+	var jsonOutput, si bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -46,8 +47,7 @@ func newPlansListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var plans []CDNPlan
@@ -60,23 +60,23 @@ func newPlansListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-15s %-20s %-15s %-15s\n", "ID", "NAME", "DISPLAY NAME", "TRAFFIC", "PRICE")
-			fmt.Println(strings.Repeat("-", 75))
+			table := output.NewTable("ID", "NAME", "DISPLAY NAME", "TRAFFIC", "PRICE")
 			for _, p := range plans {
-				traffic := formatBytes(p.Traffic)
+				traffic := util.FormatBytes(p.Traffic, byteUnits(si))
 				price := fmt.Sprintf("%d Toman", p.Price)
 				if p.Price == 0 {
 					price = "Free"
 				}
-				fmt.Printf("%-6d %-15s %-20s %-15s %-15s\n",
-					p.ID, p.Name, truncate(p.DisplayName, 20), traffic, price)
+				table.AddRow(p.ID, p.Name, p.DisplayName, traffic, price)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&si, "si", false, "Use decimal SI units (1 KB = 1000 B) instead of binary units")
 
 	return cmd
 }
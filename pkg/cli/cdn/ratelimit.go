@@ -3,12 +3,15 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
 	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 type RateLimitSettings struct {
@@ -48,14 +51,13 @@ func newRateLimitStatusCmd() *cobra.Command {
 		Short: "Get rate limit status",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/ratelimit", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/ratelimit", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var settings RateLimitSettings
@@ -65,7 +67,7 @@ func newRateLimitStatusCmd() *cobra.Command {
 
 			fmt.Printf("Rate Limit Settings\n")
 			fmt.Printf("===================\n")
-			fmt.Printf("Enabled:           %v\n", settings.Enabled.Bool())
+			fmt.Printf("Enabled:           %s\n", settings.Enabled)
 			fmt.Printf("Request Limit:     %d req/s\n", settings.Limit)
 			fmt.Printf("Block Duration:    %d seconds\n", settings.Block)
 
@@ -91,7 +93,7 @@ func newRateLimitStatusCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -103,6 +105,7 @@ func newRateLimitSetCmd() *cobra.Command {
 	var enabled bool
 	var requestCount, blockTime int
 	var methods, ips, countries []string
+	var addIPs, removeIPs []string
 
 	cmd := &cobra.Command{
 		Use:   "set",
@@ -112,60 +115,197 @@ func newRateLimitSetCmd() *cobra.Command {
   --block-time:    Block duration in seconds (1-1000)
   --methods:       Whitelisted HTTP methods (GET,POST,PUT,DELETE,etc.)
   --ips:           Whitelisted IP addresses (comma-separated)
-  --countries:     Whitelisted country codes (comma-separated, e.g., US,DE)`,
+  --countries:     Whitelisted country codes (comma-separated, e.g., US,DE)
+
+Only the settings you pass are changed; the rest keep their current values.
+--ips replaces the whole IP whitelist, while --add-ip and --remove-ip edit it.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRateLimitRange(requestCount, blockTime); err != nil {
+				return err
+			}
+			var err error
+			if methods, err = normalizeHTTPMethods(methods); err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			for _, list := range []struct {
+				flag   string
+				values []string
+			}{{"ips", ips}, {"add-ip", addIPs}, {"remove-ip", removeIPs}} {
+				if err := validate.IPOrCIDRList(list.values); err != nil {
+					return fmt.Errorf("invalid --%s: %w", list.flag, err)
+				}
+			}
+			codes, err := validate.CountryCodes(countries...)
+			if err != nil {
+				return fmt.Errorf("invalid --countries: %w", err)
+			}
+			countries = append([]string{}, codes...)
+
 			client := api.NewClient()
+			settings, err := getRateLimitSettings(client, domainID)
+			if err != nil {
+				return err
+			}
 
-			body := map[string]interface{}{
-				"mode":          enabled,
-				"request_count": requestCount,
-				"block_time":    blockTime,
-				"methods":       methods,
-				"ips":           ips,
-				"countries":     countries,
+			update := RateLimitSettings{
+				Enabled:        types.NumericBool(enabled),
+				Limit:          requestCount,
+				Block:          blockTime,
+				AllowMethods:   methods,
+				Whitelist:      ips,
+				AllowCountries: countries,
 			}
+			mergeRateLimitUpdate(settings, update, addIPs, removeIPs, flags.Changed)
 
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ratelimit", domainID), body)
+			_, err = client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ratelimit", domainID), rateLimitBody(settings))
 			if err != nil {
 				return err
 			}
 
 			enabledStr := "enabled"
-			if !enabled {
+			if !settings.Enabled {
 				enabledStr = "disabled"
 			}
 
-			fmt.Printf("Rate limiting %s\n", enabledStr)
-			fmt.Printf("Request limit: %d req/s\n", requestCount)
-			fmt.Printf("Block duration: %d seconds\n", blockTime)
+			output.Infof("Rate limiting %s\n", enabledStr)
+			output.Infof("Request limit: %d req/s\n", settings.Limit)
+			output.Infof("Block duration: %d seconds\n", settings.Block)
 
-			if len(methods) > 0 {
-				fmt.Printf("Whitelisted methods: %s\n", strings.Join(methods, ", "))
+			if len(settings.AllowMethods) > 0 {
+				output.Infof("Whitelisted methods: %s\n", strings.Join(settings.AllowMethods, ", "))
 			}
-			if len(ips) > 0 {
-				fmt.Printf("Whitelisted IPs: %s\n", strings.Join(ips, ", "))
+			if len(settings.Whitelist) > 0 {
+				output.Infof("Whitelisted IPs: %s\n", strings.Join(settings.Whitelist, ", "))
 			}
-			if len(countries) > 0 {
-				fmt.Printf("Whitelisted countries: %s\n", strings.Join(countries, ", "))
+			if len(settings.AllowCountries) > 0 {
+				output.Infof("Whitelisted countries: %s\n", strings.Join(settings.AllowCountries, ", "))
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable/disable rate limiting")
 	cmd.Flags().IntVar(&requestCount, "request-count", 100, "Max requests per second (1-1000)")
 	cmd.Flags().IntVar(&blockTime, "block-time", 60, "Block duration in seconds (1-1000)")
 	cmd.Flags().StringSliceVar(&methods, "methods", []string{}, "Whitelisted HTTP methods")
-	cmd.Flags().StringSliceVar(&ips, "ips", []string{}, "Whitelisted IP addresses")
+	cmd.Flags().StringSliceVar(&ips, "ips", []string{}, "Whitelisted IP addresses (replaces the whole list)")
+	cmd.Flags().StringSliceVar(&addIPs, "add-ip", nil, "Add IP addresses to the whitelist")
+	cmd.Flags().StringSliceVar(&removeIPs, "remove-ip", nil, "Remove IP addresses from the whitelist")
 	cmd.Flags().StringSliceVar(&countries, "countries", []string{}, "Whitelisted country codes")
 
 	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagsMutuallyExclusive("ips", "add-ip")
+	cmd.MarkFlagsMutuallyExclusive("ips", "remove-ip")
 
 	return cmd
 }
 
+// validateRateLimitRange checks --request-count and --block-time against
+// the 1-1000 range the API accepts
+func validateRateLimitRange(requestCount, blockTime int) error {
+	if requestCount < 1 || requestCount > 1000 {
+		return fmt.Errorf("invalid --request-count %d: must be between 1 and 1000 requests per second", requestCount)
+	}
+	if blockTime < 1 || blockTime > 1000 {
+		return fmt.Errorf("invalid --block-time %d: must be between 1 and 1000 seconds", blockTime)
+	}
+	return nil
+}
+
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "CONNECT", "TRACE"}
+
+// normalizeHTTPMethods upper-cases --methods and rejects unknown verbs
+func normalizeHTTPMethods(methods []string) ([]string, error) {
+	result := make([]string, 0, len(methods))
+	for _, m := range methods {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if !slices.Contains(httpMethods, m) {
+			return nil, fmt.Errorf("invalid --methods %q: must be one of %s", m, strings.Join(httpMethods, ", "))
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func getRateLimitSettings(client *api.Client, domainID int) (*RateLimitSettings, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/ratelimit", domainID))
+	if err != nil {
+		return nil, err
+	}
+
+	var settings RateLimitSettings
+	if err := json.Unmarshal(resp.Data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// mergeRateLimitUpdate copies the fields of update whose flags changed
+// reports as set onto settings, then applies --add-ip and --remove-ip to
+// the whitelist. A domain that never saved a rate limit has a zero limit
+// and block time, which the API rejects, so those fall back to the flag
+// defaults.
+func mergeRateLimitUpdate(settings *RateLimitSettings, update RateLimitSettings, addIPs, removeIPs []string, changed func(flag string) bool) {
+	if changed("enabled") {
+		settings.Enabled = update.Enabled
+	}
+	if changed("request-count") || settings.Limit < 1 {
+		settings.Limit = update.Limit
+	}
+	if changed("block-time") || settings.Block < 1 {
+		settings.Block = update.Block
+	}
+	if changed("methods") {
+		settings.AllowMethods = update.AllowMethods
+	}
+	if changed("ips") {
+		settings.Whitelist = update.Whitelist
+	}
+	if changed("countries") {
+		settings.AllowCountries = update.AllowCountries
+	}
+	settings.Whitelist = editList(settings.Whitelist, addIPs, removeIPs)
+}
+
+// rateLimitBody is the request that saves settings. The API replaces every
+// field, so lists are always sent, empty rather than null.
+func rateLimitBody(s *RateLimitSettings) map[string]interface{} {
+	orEmpty := func(list []string) []string {
+		if list == nil {
+			return []string{}
+		}
+		return list
+	}
+	return map[string]interface{}{
+		"mode":          bool(s.Enabled),
+		"request_count": s.Limit,
+		"block_time":    s.Block,
+		"methods":       orEmpty(s.AllowMethods),
+		"ips":           orEmpty(s.Whitelist),
+		"countries":     orEmpty(s.AllowCountries),
+	}
+}
+
+// editList adds and removes entries, keeping the existing order and
+// skipping duplicates
+func editList(list, add, remove []string) []string {
+	result := []string{}
+	for _, v := range list {
+		if !slices.Contains(remove, v) && !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	for _, v := range add {
+		if !slices.Contains(remove, v) && !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 func newRateLimitEnableCmd() *cobra.Command {
 	var domainID int
 	var requestCount, blockTime int
@@ -173,34 +313,45 @@ func newRateLimitEnableCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "enable",
 		Short: "Enable rate limiting",
+		Long: `Turn rate limiting on. The whitelists and methods are kept; the request
+limit and block time keep their current values unless passed, falling back
+to the flag defaults when none are set yet.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRateLimitRange(requestCount, blockTime); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
 
-			body := map[string]interface{}{
-				"mode":          true,
-				"request_count": requestCount,
-				"block_time":    blockTime,
-				"methods":       []string{},
-				"ips":           []string{},
-				"countries":     []string{},
+			// Get current settings to preserve them
+			settings, err := getRateLimitSettings(client, domainID)
+			if err != nil {
+				return err
+			}
+			settings.Enabled = true
+			if cmd.Flags().Changed("request-count") || settings.Limit < 1 {
+				settings.Limit = requestCount
+			}
+			if cmd.Flags().Changed("block-time") || settings.Block < 1 {
+				settings.Block = blockTime
 			}
 
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ratelimit", domainID), body)
+			_, err = client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ratelimit", domainID), rateLimitBody(settings))
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Rate limiting enabled")
-			fmt.Printf("Request limit: %d req/s\n", requestCount)
-			fmt.Printf("Block duration: %d seconds\n", blockTime)
+			output.Infoln("Rate limiting enabled")
+			output.Infof("Request limit: %d req/s\n", settings.Limit)
+			output.Infof("Block duration: %d seconds\n", settings.Block)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
-	cmd.Flags().IntVar(&requestCount, "request-count", 100, "Max requests per second")
-	cmd.Flags().IntVar(&blockTime, "block-time", 60, "Block duration in seconds")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&requestCount, "request-count", 100, "Max requests per second (1-1000)")
+	cmd.Flags().IntVar(&blockTime, "block-time", 60, "Block duration in seconds (1-1000)")
 
 	cmd.MarkFlagRequired("domain")
 
@@ -217,36 +368,23 @@ func newRateLimitDisableCmd() *cobra.Command {
 			client := api.NewClient()
 
 			// Get current settings to preserve them
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/ratelimit", domainID))
+			settings, err := getRateLimitSettings(client, domainID)
 			if err != nil {
 				return err
 			}
+			settings.Enabled = false
 
-			var settings RateLimitSettings
-			if err := json.Unmarshal(resp.Data, &settings); err != nil {
-				return fmt.Errorf("failed to parse settings: %w", err)
-			}
-
-			body := map[string]interface{}{
-				"mode":          false,
-				"request_count": settings.Limit,
-				"block_time":    settings.Block,
-				"methods":       settings.AllowMethods,
-				"ips":           settings.Whitelist,
-				"countries":     settings.AllowCountries,
-			}
-
-			_, err = client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/ratelimit", domainID), body)
+			_, err = client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/ratelimit", domainID), rateLimitBody(settings))
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Rate limiting disabled")
+			output.Infoln("Rate limiting disabled")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
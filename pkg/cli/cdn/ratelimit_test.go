@@ -0,0 +1,120 @@
+package cdn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditList(t *testing.T) {
+	tests := []struct {
+		name              string
+		list, add, remove []string
+		want              []string
+	}{
+		{"nil everything", nil, nil, nil, []string{}},
+		{"unchanged", []string{"a", "b"}, nil, nil, []string{"a", "b"}},
+		{"add keeps order", []string{"b", "a"}, []string{"c"}, nil, []string{"b", "a", "c"}},
+		{"add existing is skipped", []string{"a", "b"}, []string{"b", "c"}, nil, []string{"a", "b", "c"}},
+		{"add duplicates collapse", nil, []string{"a", "a"}, nil, []string{"a"}},
+		{"existing duplicates collapse", []string{"a", "a", "b"}, nil, nil, []string{"a", "b"}},
+		{"remove", []string{"a", "b", "c"}, nil, []string{"b"}, []string{"a", "c"}},
+		{"remove missing", []string{"a"}, nil, []string{"z"}, []string{"a"}},
+		{"remove wins over add", []string{"a"}, []string{"b"}, []string{"b"}, []string{"a"}},
+		{"remove all", []string{"a", "b"}, nil, []string{"a", "b"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editList(tt.list, tt.add, tt.remove); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("editList(%v, %v, %v) = %v, want %v", tt.list, tt.add, tt.remove, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeRateLimitUpdate(t *testing.T) {
+	existing := func() *RateLimitSettings {
+		return &RateLimitSettings{
+			Enabled:        true,
+			Limit:          100,
+			Block:          60,
+			AllowMethods:   []string{"GET"},
+			Whitelist:      []string{"10.0.0.0/8", "203.0.113.7"},
+			AllowCountries: []string{"IR"},
+		}
+	}
+	// What the set command's flags hold when only --request-count is
+	// passed: defaults and empty lists for everything else
+	update := RateLimitSettings{
+		Enabled:        true,
+		Limit:          250,
+		Block:          60,
+		AllowMethods:   []string{},
+		Whitelist:      []string{},
+		AllowCountries: []string{},
+	}
+
+	t.Run("only request count", func(t *testing.T) {
+		settings := existing()
+		mergeRateLimitUpdate(settings, update, nil, nil, changedFlags("request-count"))
+
+		want := existing()
+		want.Limit = 250
+		if !reflect.DeepEqual(settings, want) {
+			t.Errorf("got %+v, want %+v", settings, want)
+		}
+
+		body := rateLimitBody(settings)
+		if !reflect.DeepEqual(body["ips"], []string{"10.0.0.0/8", "203.0.113.7"}) {
+			t.Errorf("whitelist not sent: %v", body["ips"])
+		}
+		if !reflect.DeepEqual(body["countries"], []string{"IR"}) || !reflect.DeepEqual(body["methods"], []string{"GET"}) {
+			t.Errorf("lists not sent: %v", body)
+		}
+	})
+
+	t.Run("add and remove ip", func(t *testing.T) {
+		settings := existing()
+		mergeRateLimitUpdate(settings, update, []string{"198.51.100.1"}, []string{"203.0.113.7"}, changedFlags("add-ip", "remove-ip"))
+		want := []string{"10.0.0.0/8", "198.51.100.1"}
+		if !reflect.DeepEqual(settings.Whitelist, want) {
+			t.Errorf("whitelist = %v, want %v", settings.Whitelist, want)
+		}
+		if settings.Limit != 100 || !settings.Enabled {
+			t.Errorf("other settings changed: %+v", settings)
+		}
+	})
+
+	t.Run("replace ips", func(t *testing.T) {
+		settings := existing()
+		replace := update
+		replace.Whitelist = []string{"192.0.2.1"}
+		mergeRateLimitUpdate(settings, replace, nil, nil, changedFlags("ips"))
+		if !reflect.DeepEqual(settings.Whitelist, []string{"192.0.2.1"}) {
+			t.Errorf("whitelist = %v, want [192.0.2.1]", settings.Whitelist)
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		settings := existing()
+		off := update
+		off.Enabled = false
+		mergeRateLimitUpdate(settings, off, nil, nil, changedFlags("enabled"))
+		if settings.Enabled || rateLimitBody(settings)["mode"] != false {
+			t.Errorf("rate limiting should be off: %+v", settings)
+		}
+	})
+
+	t.Run("unconfigured domain", func(t *testing.T) {
+		settings := &RateLimitSettings{}
+		defaults := update
+		defaults.Limit = 100
+		mergeRateLimitUpdate(settings, defaults, []string{"198.51.100.1"}, nil, changedFlags("add-ip"))
+		if settings.Limit != 100 || settings.Block != 60 {
+			t.Errorf("limit/block = %d/%d, want the 100/60 defaults", settings.Limit, settings.Block)
+		}
+		if !reflect.DeepEqual(settings.Whitelist, []string{"198.51.100.1"}) {
+			t.Errorf("whitelist = %v, want [198.51.100.1]", settings.Whitelist)
+		}
+	})
+}
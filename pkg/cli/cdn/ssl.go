@@ -1,20 +1,30 @@
 package cdn
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/types"
 )
 
 type SSLCertificate struct {
 	ID        int    `json:"id"`
 	Type      string `json:"type"`
 	Status    string `json:"status"`
-	ExpiresAt string `json:"expires_at"`
+	ExpiresAt types.Timestamp `json:"expires_at"`
 	Domains   []string `json:"domains"`
 	CreatedAt string `json:"created_at"`
 }
@@ -22,13 +32,25 @@ type SSLCertificate struct {
 type SSLConfigs struct {
 	TLSVersion       string `json:"tls_version"`
 	HTTPSRedirect    bool   `json:"https_redirect"`
-	HSTSEnabled      bool   `json:"hsts_enabled"`
-	HSTSMaxAge       int    `json:"hsts_max_age"`
-	HSTSSubdomains   bool   `json:"hsts_include_subdomains"`
-	HSTSPreload      bool   `json:"hsts_preload"`
+	HSTSEnabled      types.NullableBool `json:"hsts_enabled"`
+	HSTSMaxAge       types.NullableInt  `json:"hsts_max_age"`
+	HSTSSubdomains   types.NullableBool `json:"hsts_include_subdomains"`
+	HSTSPreload      types.NullableBool `json:"hsts_preload"`
 	BackendProtocol  string `json:"backend_protocol"`
 	HTTP3Enabled     bool   `json:"h3_enabled"`
 	CSPOverride      bool   `json:"csp_override"`
+	AutoRenew        types.NullableBool `json:"auto_renew"`
+}
+
+// SSLInfo is the certificate summary returned by ssl/get-info
+type SSLInfo struct {
+	HasSSL      bool               `json:"has_ssl"`
+	Issuer      string             `json:"issuer"`
+	ValidFrom   string             `json:"valid_from"`
+	ValidTo     types.Timestamp    `json:"valid_to"`
+	Domains     []string           `json:"domains"`
+	Fingerprint string             `json:"fingerprint"`
+	AutoRenew   types.NullableBool `json:"auto_renew"`
 }
 
 func NewSSLCmd() *cobra.Command {
@@ -41,8 +63,11 @@ func NewSSLCmd() *cobra.Command {
 
 	cmd.AddCommand(newSSLListCmd())
 	cmd.AddCommand(newSSLStatusCmd())
+	cmd.AddCommand(newSSLExpiringCmd())
 	cmd.AddCommand(newSSLInfoCmd())
 	cmd.AddCommand(newSSLRequestFreeCmd())
+	cmd.AddCommand(newSSLRenewCmd())
+	cmd.AddCommand(newSSLAutoRenewCmd())
 	cmd.AddCommand(newSSLAddCustomCmd())
 	cmd.AddCommand(newSSLDeleteCmd())
 	cmd.AddCommand(newSSLAttachCmd())
@@ -63,37 +88,29 @@ func newSSLInfoCmd() *cobra.Command {
 		Short: "Get SSL certificate info for domain",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl/get-info", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/get-info", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
-			var info struct {
-				HasSSL      bool   `json:"has_ssl"`
-				Issuer      string `json:"issuer"`
-				ValidFrom   string `json:"valid_from"`
-				ValidTo     string `json:"valid_to"`
-				Domains     []string `json:"domains"`
-				Fingerprint string `json:"fingerprint"`
-			}
+			var info SSLInfo
 			if err := json.Unmarshal(resp.Data, &info); err != nil {
-				fmt.Println(string(resp.Data))
-				return nil
+				return fmt.Errorf("failed to parse SSL info: %w", err)
 			}
 
 			fmt.Printf("SSL Certificate Info\n")
 			fmt.Printf("====================\n")
-			fmt.Printf("Has SSL:     %v\n", info.HasSSL)
+			fmt.Printf("Has SSL:     %s\n", types.FormatBool(info.HasSSL))
 			if info.HasSSL {
 				fmt.Printf("Issuer:      %s\n", info.Issuer)
 				fmt.Printf("Valid From:  %s\n", info.ValidFrom)
 				fmt.Printf("Valid To:    %s\n", info.ValidTo)
 				fmt.Printf("Fingerprint: %s\n", info.Fingerprint)
+				fmt.Printf("Auto-Renew:  %s\n", info.AutoRenew)
 				if len(info.Domains) > 0 {
 					fmt.Printf("Domains:     %s\n", strings.Join(info.Domains, ", "))
 				}
@@ -103,7 +120,7 @@ func newSSLInfoCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -119,17 +136,17 @@ func newSSLAttachDefaultCmd() *cobra.Command {
 		Long:  "Attach the default MizbanCloud shared SSL certificate to your domain.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/attach-default", domainID), nil)
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/attach-default", domainID), nil)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Default SSL certificate attached successfully")
+			output.Infoln("Default SSL certificate attached successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -143,17 +160,17 @@ func newSSLDetachDefaultCmd() *cobra.Command {
 		Short: "Detach default MizbanCloud SSL certificate",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/detach-default", domainID), nil)
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/detach-default", domainID), nil)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Default SSL certificate detached successfully")
+			output.Infoln("Default SSL certificate detached successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -161,21 +178,24 @@ func newSSLDetachDefaultCmd() *cobra.Command {
 
 func newSSLStatusCmd() *cobra.Command {
 	var domainID int
-	var jsonOutput bool
+	var jsonOutput, noCheck bool
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Get SSL/HTTPS settings",
+		Long: `Show the domain's SSL/HTTPS settings, then connect to the domain over HTTPS
+and check the certificate chain it serves. Missing intermediates, self-signed
+or expired certificates and DNS names the certificate doesn't cover are listed
+under Warnings. Use --no-check to skip the connection.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl/get-configs", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/get-configs", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var configs SSLConfigs
@@ -186,24 +206,46 @@ func newSSLStatusCmd() *cobra.Command {
 			fmt.Printf("SSL/HTTPS Settings\n")
 			fmt.Printf("==================\n")
 			fmt.Printf("TLS Version:       %s\n", configs.TLSVersion)
-			fmt.Printf("HTTPS Redirect:    %v\n", configs.HTTPSRedirect)
+			fmt.Printf("HTTPS Redirect:    %s\n", types.FormatBool(configs.HTTPSRedirect))
 			fmt.Printf("Backend Protocol:  %s\n", configs.BackendProtocol)
-			fmt.Printf("HTTP/3 (QUIC):     %v\n", configs.HTTP3Enabled)
-			fmt.Printf("CSP Override:      %v\n", configs.CSPOverride)
+			fmt.Printf("HTTP/3 (QUIC):     %s\n", types.FormatBool(configs.HTTP3Enabled))
+			fmt.Printf("CSP Override:      %s\n", types.FormatBool(configs.CSPOverride))
+			fmt.Printf("Auto-Renew:        %s\n", configs.AutoRenew)
 			fmt.Printf("\nHSTS:\n")
-			fmt.Printf("  Enabled:         %v\n", configs.HSTSEnabled)
-			if configs.HSTSEnabled {
-				fmt.Printf("  Max Age:         %d seconds\n", configs.HSTSMaxAge)
-				fmt.Printf("  Subdomains:      %v\n", configs.HSTSSubdomains)
-				fmt.Printf("  Preload:         %v\n", configs.HSTSPreload)
+			fmt.Printf("  Enabled:         %s\n", configs.HSTSEnabled)
+			if configs.HSTSEnabled.Value {
+				if configs.HSTSMaxAge.Valid {
+					fmt.Printf("  Max Age:         %d seconds\n", configs.HSTSMaxAge.Value)
+				} else {
+					fmt.Printf("  Max Age:         not set\n")
+				}
+				fmt.Printf("  Subdomains:      %s\n", configs.HSTSSubdomains)
+				fmt.Printf("  Preload:         %s\n", configs.HSTSPreload)
+			}
+
+			if noCheck {
+				return nil
+			}
+			warnings := domainTLSWarnings(client, domainID)
+			// Lookup failures are warnings, but Ctrl-C and --timeout still stop
+			if err := client.Context().Err(); err != nil {
+				return err
+			}
+			fmt.Printf("\nWarnings:\n")
+			if len(warnings) == 0 {
+				fmt.Printf("  none: the served certificate chain is valid\n")
+			}
+			for _, w := range warnings {
+				fmt.Printf("  - %s\n", w)
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&noCheck, "no-check", false, "Don't connect to the domain to check the served certificate")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -218,7 +260,7 @@ func newSSLAttachCmd() *cobra.Command {
 		Short: "Attach SSL certificate to DNS records",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/attach", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/attach", domainID), map[string]interface{}{
 				"certificate_id": certID,
 				"record_ids":     recordIDs,
 			})
@@ -226,12 +268,12 @@ func newSSLAttachCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("SSL certificate attached successfully")
+			output.Infoln("SSL certificate attached successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntVar(&certID, "cert", 0, "Certificate ID")
 	cmd.Flags().IntSliceVar(&recordIDs, "records", nil, "DNS record IDs to attach")
 	cmd.MarkFlagRequired("domain")
@@ -250,19 +292,19 @@ func newSSLDetachCmd() *cobra.Command {
 		Short: "Detach SSL certificate from DNS records",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/detach", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/detach", domainID), map[string]interface{}{
 				"record_ids": recordIDs,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("SSL certificate detached successfully")
+			output.Infoln("SSL certificate detached successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().IntSliceVar(&recordIDs, "records", nil, "DNS record IDs to detach")
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("records")
@@ -279,20 +321,13 @@ func newSSLListCmd() *cobra.Command {
 		Short: "List SSL certificates",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl", domainID))
+			certs, err := getSSLCertificates(client, domainID)
 			if err != nil {
 				return err
 			}
 
-			var certs []SSLCertificate
-			if err := json.Unmarshal(resp.Data, &certs); err != nil {
-				return fmt.Errorf("failed to parse certificates: %w", err)
-			}
-
 			if jsonOutput {
-				output, _ := json.MarshalIndent(certs, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(certs)
 			}
 
 			if len(certs) == 0 {
@@ -300,90 +335,400 @@ func newSSLListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-12s %-12s %-25s %-30s\n", "ID", "TYPE", "STATUS", "EXPIRES", "DOMAINS")
-			fmt.Println(strings.Repeat("-", 90))
+			table := output.NewTable("ID", "TYPE", "STATUS", "EXPIRES", "DOMAINS")
 			for _, c := range certs {
 				domains := strings.Join(c.Domains, ", ")
-				fmt.Printf("%-6d %-12s %-12s %-25s %-30s\n",
-					c.ID, c.Type, c.Status, c.ExpiresAt, truncate(domains, 30))
+				table.AddRow(c.ID, c.Type, c.Status, c.ExpiresAt, domains)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
 }
 
-func newSSLRequestFreeCmd() *cobra.Command {
+// expiringCert is a certificate found by ssl expiring
+type expiringCert struct {
+	DomainID int            `json:"domain_id"`
+	Domain   string         `json:"domain"`
+	Cert     SSLCertificate `json:"certificate"`
+	DaysLeft int            `json:"days_left"`
+}
+
+func newSSLExpiringCmd() *cobra.Command {
+	var domainID, days int
+	var allDomains, jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "expiring",
+		Short: "List certificates that expire soon",
+		Long:  "List certificates expiring within --days (expired ones included). Exits non-zero when any are found, so it can gate a monitoring job.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 0 {
+				return fmt.Errorf("invalid --days %d: must not be negative", days)
+			}
+
+			client := api.NewClient()
+			domains := []Domain{{ID: domainID}}
+			if allDomains {
+				var err error
+				domains, _, err = api.GetList[Domain](client, "/v1/cdn/ng/domains", api.PageOptions{All: true})
+				if err != nil {
+					return err
+				}
+			}
+
+			now := time.Now()
+			var expiring []expiringCert
+			endpoints := make([]string, len(domains))
+			for i, d := range domains {
+				endpoints[i] = sslCertificatesEndpoint(d.ID)
+			}
+			results, _ := client.ParallelGet(endpoints, 0)
+			for i, d := range domains {
+				certs, err := parseSSLCertificates(results[i].Response, results[i].Err)
+				if err != nil {
+					return fmt.Errorf("domain %d: %w", d.ID, err)
+				}
+
+				for _, c := range certs {
+					if c.ExpiresAt.IsZero() {
+						if c.ExpiresAt.String() != "" {
+							fmt.Fprintf(os.Stderr, "Warning: certificate %d: unrecognized expiry %q\n", c.ID, c.ExpiresAt)
+						}
+						continue
+					}
+					daysLeft := int(math.Floor(c.ExpiresAt.Time().Sub(now).Hours() / 24))
+					if daysLeft <= days {
+						expiring = append(expiring, expiringCert{DomainID: d.ID, Domain: d.Name, Cert: c, DaysLeft: daysLeft})
+					}
+				}
+			}
+
+			sort.Slice(expiring, func(i, j int) bool { return expiring[i].DaysLeft < expiring[j].DaysLeft })
+
+			if jsonOutput {
+				if err := output.PrintJSONValue(expiring); err != nil {
+					return err
+				}
+			} else if len(expiring) == 0 {
+				fmt.Printf("No certificates expire within %d days\n", days)
+			} else {
+				table := output.NewTable("DOMAIN", "CERT ID", "TYPE", "EXPIRES", "DAYS LEFT")
+				for _, e := range expiring {
+					domain := e.Domain
+					if domain == "" {
+						domain = strconv.Itoa(e.DomainID)
+					}
+					table.AddRow(domain, e.Cert.ID, e.Cert.Type, e.Cert.ExpiresAt, e.DaysLeft)
+				}
+				table.Render()
+			}
+
+			if len(expiring) > 0 {
+				return fmt.Errorf("%d certificates expire within %d days", len(expiring), days)
+			}
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&allDomains, "all-domains", false, "Check every domain in the account")
+	cmd.Flags().IntVar(&days, "days", 30, "Report certificates expiring within this many days")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagsOneRequired("domain", "all-domains")
+	cmd.MarkFlagsMutuallyExclusive("domain", "all-domains")
+
+	return cmd
+}
+
+func newSSLRenewCmd() *cobra.Command {
+	var domainID, certID int
+
+	cmd := &cobra.Command{
+		Use:   "renew",
+		Short: "Renew a Let's Encrypt certificate",
+		Long:  "Ask for a free Let's Encrypt certificate to be reissued now. Without --cert-id, the domain's Let's Encrypt certificate is renewed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			certs, err := getSSLCertificates(client, domainID)
+			if err != nil {
+				return err
+			}
+
+			var cert *SSLCertificate
+			for i, c := range certs {
+				if (certID > 0 && c.ID == certID) || (certID == 0 && isFreeCertificate(c)) {
+					cert = &certs[i]
+					break
+				}
+			}
+			if cert == nil {
+				if certID > 0 {
+					return fmt.Errorf("certificate %d not found on domain %d", certID, domainID)
+				}
+				return fmt.Errorf("domain %d has no Let's Encrypt certificate; request one with 'mizban ssl request-free'", domainID)
+			}
+			if !isFreeCertificate(*cert) {
+				return fmt.Errorf("certificate %d is a %s certificate; only Let's Encrypt certificates can be renewed", cert.ID, cert.Type)
+			}
+			if renewalInProgress(cert.Status) {
+				fmt.Printf("Renewal of certificate %d is already in progress (status: %s)\n", cert.ID, cert.Status)
+				return nil
+			}
+
+			resp, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/%d/renew", domainID, cert.ID), nil)
+			if api.HasStatus(err, http.StatusConflict) {
+				fmt.Printf("Renewal of certificate %d is already in progress\n", cert.ID)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Renewal of certificate %d requested (current expiry: %s)\n", cert.ID, cert.ExpiresAt)
+
+			var renewed SSLCertificate
+			if json.Unmarshal(resp.Data, &renewed) == nil && !renewed.ExpiresAt.IsZero() && renewed.ExpiresAt.String() != cert.ExpiresAt.String() {
+				fmt.Printf("New expiry: %s\n", renewed.ExpiresAt)
+			} else {
+				fmt.Println("The new certificate will be issued within a few minutes; check its expiry with 'mizban ssl list'.")
+			}
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&certID, "cert-id", 0, "Certificate ID (default: the domain's Let's Encrypt certificate)")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func getSSLCertificates(client *api.Client, domainID int) ([]SSLCertificate, error) {
+	return parseSSLCertificates(client.Get(sslCertificatesEndpoint(domainID)))
+}
+
+func sslCertificatesEndpoint(domainID int) string {
+	return api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl", domainID)
+}
+
+// parseSSLCertificates decodes the response of sslCertificatesEndpoint
+func parseSSLCertificates(resp *api.Response, err error) ([]SSLCertificate, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []SSLCertificate
+	if err := json.Unmarshal(resp.Data, &certs); err != nil {
+		return nil, fmt.Errorf("failed to parse certificates: %w", err)
+	}
+	return certs, nil
+}
+
+// isFreeCertificate reports whether c was issued through request-free
+func isFreeCertificate(c SSLCertificate) bool {
+	t := strings.ToLower(c.Type)
+	return strings.Contains(t, "free") || strings.Contains(t, "letsencrypt") || strings.Contains(t, "let's encrypt")
+}
+
+func renewalInProgress(status string) bool {
+	switch strings.ToLower(status) {
+	case "pending", "processing", "issuing", "renewing":
+		return true
+	}
+	return false
+}
+
+func newSSLAutoRenewCmd() *cobra.Command {
 	var domainID int
+	var enabled bool
 
 	cmd := &cobra.Command{
-		Use:   "request-free",
-		Short: "Request free Let's Encrypt SSL certificate",
+		Use:   "auto-renew",
+		Short: "Turn automatic renewal of the free certificate on or off",
+		Long:  "Control whether the platform renews the domain's free certificate before it expires. With auto-renewal off, certificates must be renewed with ssl renew. The current setting is shown by ssl status and ssl info.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl/free", domainID), nil)
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/auto-renew", domainID), map[string]interface{}{
+				"enabled": enabled,
+			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("SSL certificate request submitted successfully!")
-			fmt.Println("The certificate will be issued within a few minutes.")
+			if enabled {
+				output.Infoln("SSL auto-renewal enabled")
+			} else {
+				output.Infoln("SSL auto-renewal disabled; renew certificates with 'mizban ssl renew'")
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&enabled, "enabled", true, "Renew the free certificate automatically")
 	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("enabled")
 
 	return cmd
 }
 
+func newSSLRequestFreeCmd() *cobra.Command {
+	var domainID int
+
+	cmd := &cobra.Command{
+		Use:   "request-free",
+		Short: "Request free Let's Encrypt SSL certificate",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return requestFreeSSL(api.NewClient(), domainID)
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func requestFreeSSL(client *api.Client, domainID int) error {
+	_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/free", domainID), nil)
+	if err != nil {
+		return err
+	}
+
+	output.Infoln("SSL certificate request submitted successfully!")
+	output.Infoln("The certificate will be issued within a few minutes.")
+	return nil
+}
+
 func newSSLAddCustomCmd() *cobra.Command {
 	var domainID int
 	var certificate, privateKey, chain string
+	var certFile, keyFile, chainFile string
 
 	cmd := &cobra.Command{
 		Use:   "add-custom",
 		Short: "Add custom SSL certificate",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			certPEM, err := readPEMInput(certificate, certFile, "certificate")
+			if err != nil {
+				return err
+			}
+			keyPEM, err := readPEMInput(privateKey, keyFile, "private key")
+			if err != nil {
+				return err
+			}
+			chainPEM, err := readPEMInput(chain, chainFile, "chain")
+			if err != nil {
+				return err
+			}
+
+			if err := validatePEM(certPEM, "certificate", isCertificateBlock); err != nil {
+				return err
+			}
+			if err := validatePEM(keyPEM, "private key", isPrivateKeyBlock); err != nil {
+				return err
+			}
+			if chainPEM != "" {
+				if err := validatePEM(chainPEM, "chain", isCertificateBlock); err != nil {
+					return err
+				}
+			}
+			if _, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM)); err != nil {
+				return fmt.Errorf("private key does not match certificate: %w", err)
+			}
+
 			client := api.NewClient()
 
 			body := map[string]interface{}{
-				"certificate": certificate,
-				"private_key": privateKey,
+				"certificate": certPEM,
+				"private_key": keyPEM,
 			}
-			if chain != "" {
-				body["chain"] = chain
+			if chainPEM != "" {
+				body["chain"] = chainPEM
 			}
 
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl/add", domainID), body)
+			_, err = client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/add", domainID), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Custom SSL certificate added successfully!")
+			output.Infoln("Custom SSL certificate added successfully!")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&certificate, "cert", "", "Certificate PEM content")
 	cmd.Flags().StringVar(&privateKey, "key", "", "Private key PEM content")
 	cmd.Flags().StringVar(&chain, "chain", "", "Certificate chain PEM content (optional)")
+	cmd.Flags().StringVar(&certFile, "cert-file", "", "Path to certificate PEM file")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Path to private key PEM file")
+	cmd.Flags().StringVar(&chainFile, "chain-file", "", "Path to certificate chain PEM file (optional)")
 
 	cmd.MarkFlagRequired("domain")
-	cmd.MarkFlagRequired("cert")
-	cmd.MarkFlagRequired("key")
+	cmd.MarkFlagsOneRequired("cert", "cert-file")
+	cmd.MarkFlagsOneRequired("key", "key-file")
+	cmd.MarkFlagsMutuallyExclusive("cert", "cert-file")
+	cmd.MarkFlagsMutuallyExclusive("key", "key-file")
+	cmd.MarkFlagsMutuallyExclusive("chain", "chain-file")
 
 	return cmd
 }
 
+// readPEMInput returns the inline PEM value, or the contents of path when
+// given, with trailing whitespace removed
+func readPEMInput(inline, path, name string) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s file: %w", name, err)
+		}
+		inline = string(data)
+	}
+	return strings.TrimRight(inline, " \t\r\n"), nil
+}
+
+// validatePEM checks that content holds at least one PEM block and that
+// every block is accepted by allowed
+func validatePEM(content, name string, allowed func(*pem.Block) bool) error {
+	rest := []byte(content)
+	count := 0
+	for {
+		block, next := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if !allowed(block) {
+			return fmt.Errorf("invalid %s: unexpected PEM block %q", name, block.Type)
+		}
+		count++
+		rest = next
+	}
+	if count == 0 {
+		return fmt.Errorf("invalid %s: no PEM data found", name)
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return fmt.Errorf("invalid %s: unexpected data after PEM blocks", name)
+	}
+	return nil
+}
+
+func isCertificateBlock(block *pem.Block) bool {
+	return block.Type == "CERTIFICATE"
+}
+
+func isPrivateKeyBlock(block *pem.Block) bool {
+	return strings.HasSuffix(block.Type, "PRIVATE KEY")
+}
+
 func newSSLDeleteCmd() *cobra.Command {
 	var domainID int
 
@@ -393,17 +738,21 @@ func newSSLDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl/%s", domainID, args[0]))
+			_, err := client.Delete(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/%s", domainID, args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("SSL certificate already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("SSL certificate deleted successfully")
+			output.Infoln("SSL certificate deleted successfully")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -434,19 +783,19 @@ func newSSLTLSVersionCmd() *cobra.Command {
 		Short: "Set minimum TLS version",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/ssl/tls-version", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/ssl/tls-version", domainID), map[string]interface{}{
 				"min_version": minVersion,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Minimum TLS version set to %s\n", minVersion)
+			output.Infof("Minimum TLS version set to %s\n", minVersion)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&minVersion, "version", "1.2", "Minimum TLS version (1.0, 1.1, 1.2, 1.3)")
 
 	cmd.MarkFlagRequired("domain")
@@ -465,7 +814,7 @@ func newSSLHSTSCmd() *cobra.Command {
 		Short: "Configure HSTS settings",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/hsts", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/hsts", domainID), map[string]interface{}{
 				"enabled":            enabled,
 				"max_age":            maxAge,
 				"include_subdomains": includeSubdomains,
@@ -476,15 +825,15 @@ func newSSLHSTSCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("HSTS enabled successfully")
+				output.Infoln("HSTS enabled successfully")
 			} else {
-				fmt.Println("HSTS disabled successfully")
+				output.Infoln("HSTS disabled successfully")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable HSTS")
 	cmd.Flags().IntVar(&maxAge, "max-age", 31536000, "Max age in seconds")
 	cmd.Flags().BoolVar(&includeSubdomains, "include-subdomains", false, "Include subdomains")
@@ -504,7 +853,7 @@ func newSSLRedirectCmd() *cobra.Command {
 		Short: "Enable/disable HTTPS redirect",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/redirect", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/redirect", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -512,15 +861,15 @@ func newSSLRedirectCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("HTTPS redirect enabled")
+				output.Infoln("HTTPS redirect enabled")
 			} else {
-				fmt.Println("HTTPS redirect disabled")
+				output.Infoln("HTTPS redirect disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable HTTPS redirect")
 
 	cmd.MarkFlagRequired("domain")
@@ -541,7 +890,7 @@ func newSSLBackendProtocolCmd() *cobra.Command {
   - auto:  Auto-detect based on DNS record settings`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/backend-protocol", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/backend-protocol", domainID), map[string]interface{}{
 				"protocol": protocol,
 			})
 			if err != nil {
@@ -553,7 +902,7 @@ func newSSLBackendProtocolCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&protocol, "protocol", "https", "Protocol (http/https/auto)")
 	cmd.MarkFlagRequired("domain")
 
@@ -569,7 +918,7 @@ func newSSLH3Cmd() *cobra.Command {
 		Short: "Enable/disable HTTP/3 (QUIC)",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/h3", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/h3", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -577,15 +926,15 @@ func newSSLH3Cmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("HTTP/3 (QUIC) enabled")
+				output.Infoln("HTTP/3 (QUIC) enabled")
 			} else {
-				fmt.Println("HTTP/3 (QUIC) disabled")
+				output.Infoln("HTTP/3 (QUIC) disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable HTTP/3")
 	cmd.MarkFlagRequired("domain")
 
@@ -602,7 +951,7 @@ func newSSLCSPOverrideCmd() *cobra.Command {
 		Long:  "When enabled, CDN will modify CSP headers to allow CDN resources.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/https/csp-override", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/https/csp-override", domainID), map[string]interface{}{
 				"enabled": enabled,
 			})
 			if err != nil {
@@ -610,15 +959,15 @@ func newSSLCSPOverrideCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Println("CSP override enabled")
+				output.Infoln("CSP override enabled")
 			} else {
-				fmt.Println("CSP override disabled")
+				output.Infoln("CSP override disabled")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable CSP override")
 	cmd.MarkFlagRequired("domain")
 
@@ -0,0 +1,142 @@
+package cdn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/mizbancloud/cli/pkg/api"
+)
+
+// sslCheckTimeout bounds the TLS handshake used to inspect the served
+// certificate
+const sslCheckTimeout = 10 * time.Second
+
+// domainTLSWarnings connects to a domain the way a client would and reports
+// problems with the certificate chain it serves: missing intermediates,
+// self-signed or expired certificates, and names it doesn't cover. Lookups
+// that fail are reported as warnings too, so the check never fails the
+// command it is part of.
+func domainTLSWarnings(client *api.Client, domainID int) []string {
+	domain, err := getDomain(client, domainID)
+	if err != nil {
+		return []string{fmt.Sprintf("could not look up domain %d to check the served certificate: %v", domainID, err)}
+	}
+	host := domain.displayName()
+
+	var warnings []string
+	names := []string{host}
+	records, err := getDNSRecords(client, domainID)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("could not list DNS records, so only %s was checked against the certificate: %v", host, err))
+	}
+	for _, r := range records {
+		if !r.Proxied() || !isHostRecordType(r.Type) {
+			continue
+		}
+		if name := recordHostname(r.Name, host); name != "" && !containsFold(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: sslCheckTimeout},
+		// Verification is done below so every problem can be reported,
+		// rather than stopping at the first one the handshake hits
+		Config: &tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(client.Context(), "tcp", net.JoinHostPort(host, "443"))
+	if err != nil {
+		return append(warnings, fmt.Sprintf("could not connect to %s:443 to check the served certificate: %v", host, err))
+	}
+	defer conn.Close()
+
+	chain := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	return append(warnings, certificateWarnings(chain, names, time.Now())...)
+}
+
+// certificateWarnings checks a served chain, leaf first, against the names
+// it should cover
+func certificateWarnings(chain []*x509.Certificate, names []string, now time.Time) []string {
+	if len(chain) == 0 {
+		return []string{"the server sent no certificate"}
+	}
+	leaf := chain[0]
+	var warnings []string
+
+	for i, cert := range chain {
+		role := "intermediate certificate"
+		if i == 0 {
+			role = "certificate"
+		}
+		if now.After(cert.NotAfter) {
+			warnings = append(warnings, fmt.Sprintf("%s %q expired on %s", role, cert.Subject.CommonName, cert.NotAfter.Format("2006-01-02")))
+		} else if now.Before(cert.NotBefore) {
+			warnings = append(warnings, fmt.Sprintf("%s %q is not valid until %s", role, cert.Subject.CommonName, cert.NotBefore.Format("2006-01-02")))
+		}
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{Intermediates: intermediates, CurrentTime: now})
+	var unknown x509.UnknownAuthorityError
+	switch {
+	case err == nil:
+	case isSelfSigned(leaf):
+		warnings = append(warnings, "the certificate is self-signed; clients other than those that trust it explicitly will reject it")
+	case errors.As(err, &unknown) && len(chain) == 1:
+		warnings = append(warnings, fmt.Sprintf("the chain is incomplete: no intermediate certificate is served for issuer %q; browsers may cope but curl and most API clients will fail", leaf.Issuer.CommonName))
+	case errors.As(err, &unknown):
+		warnings = append(warnings, fmt.Sprintf("the chain does not lead to a trusted root (issuer %q); an intermediate may be missing or self-signed", unknown.Cert.Issuer.CommonName))
+	default:
+		var invalid x509.CertificateInvalidError
+		if !errors.As(err, &invalid) || invalid.Reason != x509.Expired {
+			warnings = append(warnings, fmt.Sprintf("the chain failed verification: %v", err))
+		}
+	}
+
+	for _, name := range names {
+		if err := leaf.VerifyHostname(name); err != nil {
+			warnings = append(warnings, fmt.Sprintf("the certificate does not cover %s", name))
+		}
+	}
+	return warnings
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
+// isHostRecordType reports record types that clients connect to by name
+func isHostRecordType(recordType string) bool {
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA", "CNAME":
+		return true
+	}
+	return false
+}
+
+// recordHostname turns a record name such as "www" or "@" into the full
+// hostname under domain. Wildcard names are skipped since there's no single
+// host to check.
+func recordHostname(name, domain string) string {
+	name = strings.TrimSuffix(name, ".")
+	switch {
+	case strings.HasPrefix(name, "*"):
+		return ""
+	case name == "@" || name == "" || strings.EqualFold(name, domain):
+		return domain
+	case strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(domain)):
+		return name
+	}
+	return name + "." + domain
+}
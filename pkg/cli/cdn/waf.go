@@ -3,11 +3,17 @@ package cdn
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 type WAFRule struct {
@@ -15,6 +21,9 @@ type WAFRule struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Enabled     bool   `json:"enabled"`
+	Category    string `json:"category,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Group       string `json:"group,omitempty"`
 }
 
 type WAFLayer struct {
@@ -29,6 +38,22 @@ type WAFGroup struct {
 	Enabled bool   `json:"enabled"`
 }
 
+// WAFEvent is a request the WAF matched against one of its rules
+type WAFEvent struct {
+	Time     types.Timestamp `json:"time"`
+	ClientIP string          `json:"client_ip"`
+	RuleID   string          `json:"rule_id"`
+	Method   string          `json:"method,omitempty"`
+	URI      string          `json:"uri"`
+	Action   string          `json:"action"`
+}
+
+// WAFStatus is the on/off state and mode returned by waf status
+type WAFStatus struct {
+	Enabled bool   `json:"enabled"`
+	Mode    string `json:"mode"`
+}
+
 func NewWAFCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "waf",
@@ -43,6 +68,9 @@ func NewWAFCmd() *cobra.Command {
 	cmd.AddCommand(newWAFRulesCmd())
 	cmd.AddCommand(newWAFGroupsCmd())
 	cmd.AddCommand(newWAFFirewallCmd())
+	cmd.AddCommand(newWAFEventsCmd())
+	cmd.AddCommand(newWAFExportCmd())
+	cmd.AddCommand(newWAFImportCmd())
 
 	return cmd
 }
@@ -56,20 +84,16 @@ func newWAFStatusCmd() *cobra.Command {
 		Short: "Get WAF status",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/waf", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
-			var status struct {
-				Enabled bool   `json:"enabled"`
-				Mode    string `json:"mode"`
-			}
+			var status WAFStatus
 			if err := json.Unmarshal(resp.Data, &status); err != nil {
 				return fmt.Errorf("failed to parse status: %w", err)
 			}
@@ -86,7 +110,7 @@ func newWAFStatusCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -102,7 +126,7 @@ func newWAFEnableCmd() *cobra.Command {
 		Short: "Enable WAF",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf", domainID), map[string]interface{}{
+			_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/waf", domainID), map[string]interface{}{
 				"enabled": true,
 				"mode":    mode,
 			})
@@ -110,12 +134,12 @@ func newWAFEnableCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("WAF enabled (mode: %s)\n", mode)
+			output.Infof("WAF enabled (mode: %s)\n", mode)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&mode, "mode", "block", "WAF mode (block/simulate)")
 
 	cmd.MarkFlagRequired("domain")
@@ -131,19 +155,19 @@ func newWAFDisableCmd() *cobra.Command {
 		Short: "Disable WAF",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf", domainID), map[string]interface{}{
+			_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/waf", domainID), map[string]interface{}{
 				"enabled": false,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("WAF disabled")
+			output.Infoln("WAF disabled")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.MarkFlagRequired("domain")
 
 	return cmd
@@ -158,14 +182,13 @@ func newWAFLayersCmd() *cobra.Command {
 		Short: "List WAF layers",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf/layers", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/waf/layers", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var layers []WAFLayer
@@ -178,21 +201,99 @@ func newWAFLayersCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-20s %-30s %-10s\n", "ID", "NAME", "ENABLED")
-			fmt.Println(strings.Repeat("-", 65))
+			table := output.NewTable("ID", "NAME", "ENABLED")
 			for _, l := range layers {
-				enabled := "No"
-				if l.Enabled {
-					enabled = "Yes"
+				table.AddRow(l.ID, l.Name, types.FormatBool(l.Enabled))
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func newWAFEventsCmd() *cobra.Command {
+	var domainID, limit int
+	var sinceFlag string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Show recent requests matched by the WAF",
+		Long:  "Show recent requests the WAF blocked or logged, with the rule that fired for each one.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 1 {
+				return fmt.Errorf("invalid --limit %d: must be a positive number", limit)
+			}
+
+			query := url.Values{}
+			query.Set("limit", strconv.Itoa(limit))
+			var since time.Time
+			if sinceFlag != "" {
+				var err error
+				if since, err = util.ParseSince(sinceFlag); err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
 				}
-				fmt.Printf("%-20s %-30s %-10s\n", l.ID, truncate(l.Name, 30), enabled)
+				query.Set("since", since.UTC().Format(time.RFC3339))
 			}
 
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/waf/events", domainID) + "?" + query.Encode())
+			if err != nil {
+				return err
+			}
+
+			var events []WAFEvent
+			if err := json.Unmarshal(resp.Data, &events); err != nil {
+				return fmt.Errorf("failed to parse WAF events: %w", err)
+			}
+
+			// The API may ignore these parameters, so filter locally as well
+			if !since.IsZero() {
+				var recent []WAFEvent
+				for _, e := range events {
+					if e.Time.IsZero() || !e.Time.Time().Before(since) {
+						recent = append(recent, e)
+					}
+				}
+				events = recent
+			}
+			if len(events) > limit {
+				events = events[:limit]
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(events)
+			}
+
+			if len(events) == 0 {
+				fmt.Println("No WAF events found")
+				return nil
+			}
+
+			table := output.NewTable("TIME", "CLIENT IP", "RULE ID", "URI", "ACTION")
+			for _, e := range events {
+				uri := e.URI
+				if e.Method != "" {
+					uri = e.Method + " " + uri
+				}
+				table.AddRow(e.Time, e.ClientIP, e.RuleID, util.Truncate(uri, 60), e.Action)
+			}
+			table.Render()
+
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of events to show")
+	cmd.Flags().StringVar(&sinceFlag, "since", "", "Only show events after this time (duration like 2h, or a timestamp)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -201,11 +302,13 @@ func newWAFLayersCmd() *cobra.Command {
 
 func newWAFRulesCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "rules",
-		Short: "Manage WAF rules",
+		Use:     "rules",
+		Aliases: []string{"rule"},
+		Short:   "Manage WAF rules",
 	}
 
 	cmd.AddCommand(newWAFRulesListCmd())
+	cmd.AddCommand(newWAFRuleInfoCmd())
 	cmd.AddCommand(newWAFRulesDisabledCmd())
 	cmd.AddCommand(newWAFRuleToggleCmd())
 
@@ -221,7 +324,7 @@ func newWAFRulesListCmd() *cobra.Command {
 		Short: "List WAF rules",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf/rules", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/waf/rules", domainID))
 			if err != nil {
 				return err
 			}
@@ -232,9 +335,7 @@ func newWAFRulesListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(rules, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(rules)
 			}
 
 			if len(rules) == 0 {
@@ -242,21 +343,79 @@ func newWAFRulesListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-20s %-30s %-10s\n", "ID", "NAME", "ENABLED")
-			fmt.Println(strings.Repeat("-", 65))
+			table := output.NewTable("ID", "NAME", "ENABLED")
 			for _, r := range rules {
-				enabled := "No"
-				if r.Enabled {
-					enabled = "Yes"
+				table.AddRow(r.ID, r.Name, types.FormatBool(r.Enabled))
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func newWAFRuleInfoCmd() *cobra.Command {
+	var domainID int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "info [rule-id]",
+		Short: "Show details of a WAF rule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/waf/rules", domainID))
+			if err != nil {
+				return err
+			}
+
+			var rules []WAFRule
+			if err := json.Unmarshal(resp.Data, &rules); err != nil {
+				return fmt.Errorf("failed to parse rules: %w", err)
+			}
+
+			var rule *WAFRule
+			for i := range rules {
+				if rules[i].ID == args[0] {
+					rule = &rules[i]
+					break
 				}
-				fmt.Printf("%-20s %-30s %-10s\n", r.ID, truncate(r.Name, 30), enabled)
+			}
+			if rule == nil {
+				return fmt.Errorf("WAF rule %s not found", args[0])
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(rule)
+			}
+
+			fmt.Printf("ID:          %s\n", rule.ID)
+			fmt.Printf("Name:        %s\n", rule.Name)
+			fmt.Printf("Enabled:     %s\n", types.FormatBool(rule.Enabled))
+			if rule.Group != "" {
+				fmt.Printf("Group:       %s\n", rule.Group)
+			}
+			if rule.Category != "" {
+				fmt.Printf("Category:    %s\n", rule.Category)
+			}
+			if rule.Severity != "" {
+				fmt.Printf("Severity:    %s\n", rule.Severity)
+			}
+			if rule.Description != "" {
+				fmt.Printf("Description: %s\n", rule.Description)
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -272,14 +431,13 @@ func newWAFRulesDisabledCmd() *cobra.Command {
 		Short: "List disabled WAF rules",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf/disabled-rules", domainID))
+			resp, err := client.Get(api.Endpoint("/v1/cdn/ng/domains/%d/waf/disabled-rules", domainID))
 			if err != nil {
 				return err
 			}
 
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
-				return nil
+				return output.PrintJSON(resp.Data, true)
 			}
 
 			var rules []WAFRule
@@ -292,17 +450,17 @@ func newWAFRulesDisabledCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-20s %-40s\n", "ID", "NAME")
-			fmt.Println(strings.Repeat("-", 65))
+			table := output.NewTable("ID", "NAME")
 			for _, r := range rules {
-				fmt.Printf("%-20s %-40s\n", r.ID, truncate(r.Name, 40))
+				table.AddRow(r.ID, r.Name)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.MarkFlagRequired("domain")
 
@@ -319,7 +477,7 @@ func newWAFRuleToggleCmd() *cobra.Command {
 		Short: "Enable/disable a WAF rule",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf/switch-rule", domainID), map[string]interface{}{
+			_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/waf/switch-rule", domainID), map[string]interface{}{
 				"rule_id": ruleID,
 				"enabled": enabled,
 			})
@@ -328,15 +486,15 @@ func newWAFRuleToggleCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Printf("WAF rule %s enabled\n", ruleID)
+				output.Infof("WAF rule %s enabled\n", ruleID)
 			} else {
-				fmt.Printf("WAF rule %s disabled\n", ruleID)
+				output.Infof("WAF rule %s disabled\n", ruleID)
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&ruleID, "rule", "", "Rule ID")
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable/disable rule")
 
@@ -367,7 +525,7 @@ func newWAFGroupToggleCmd() *cobra.Command {
 		Short: "Enable/disable a WAF rule group",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put(fmt.Sprintf("/v1/cdn/ng/domains/%d/waf/switch-group", domainID), map[string]interface{}{
+			_, err := client.Put(api.Endpoint("/v1/cdn/ng/domains/%d/waf/switch-group", domainID), map[string]interface{}{
 				"group_id": groupID,
 				"enabled":  enabled,
 			})
@@ -376,15 +534,15 @@ func newWAFGroupToggleCmd() *cobra.Command {
 			}
 
 			if enabled {
-				fmt.Printf("WAF group %s enabled\n", groupID)
+				output.Infof("WAF group %s enabled\n", groupID)
 			} else {
-				fmt.Printf("WAF group %s disabled\n", groupID)
+				output.Infof("WAF group %s disabled\n", groupID)
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&groupID, "group", "", "Group ID")
 	cmd.Flags().BoolVar(&enabled, "enabled", true, "Enable/disable group")
 
@@ -416,8 +574,12 @@ func newWAFBlockIPCmd() *cobra.Command {
 		Use:   "block-ip",
 		Short: "Block an IP address",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validate.IPOrCIDR(ip); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
 				"ip":     ip,
 				"action": action,
 			})
@@ -425,12 +587,12 @@ func newWAFBlockIPCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("IP %s added with action: %s\n", ip, action)
+			output.Infof("IP %s added with action: %s\n", ip, action)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&ip, "ip", "", "IP address or CIDR")
 	cmd.Flags().StringVar(&action, "action", "block", "Action (block/allow/challenge)")
 
@@ -448,8 +610,12 @@ func newWAFUnblockIPCmd() *cobra.Command {
 		Use:   "unblock-ip",
 		Short: "Remove IP from firewall",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validate.IPOrCIDR(ip); err != nil {
+				return err
+			}
+
 			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+			_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
 				"ip":     ip,
 				"action": "remove",
 			})
@@ -457,12 +623,12 @@ func newWAFUnblockIPCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Printf("IP %s removed from firewall\n", ip)
+			output.Infof("IP %s removed from firewall\n", ip)
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
+	addDomainFlag(cmd, &domainID)
 	cmd.Flags().StringVar(&ip, "ip", "", "IP address")
 
 	cmd.MarkFlagRequired("domain")
@@ -479,22 +645,29 @@ func newWAFBlockCountryCmd() *cobra.Command {
 		Use:   "block-country",
 		Short: "Block a country",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
-				"country": country,
-				"action":  "block",
-			})
+			codes, err := validate.CountryCodes(country)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Country %s blocked\n", country)
+			client := api.NewClient()
+			for _, code := range codes {
+				_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+					"country": code,
+					"action":  "block",
+				})
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Country %s blocked\n", code)
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
-	cmd.Flags().StringVar(&country, "country", "", "Country code (e.g., US, DE)")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&country, "country", "", "ISO country code, or a comma-separated list (e.g., US,DE)")
 
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("country")
@@ -510,22 +683,29 @@ func newWAFUnblockCountryCmd() *cobra.Command {
 		Use:   "unblock-country",
 		Short: "Unblock a country",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := api.NewClient()
-			_, err := client.Post(fmt.Sprintf("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
-				"country": country,
-				"action":  "remove",
-			})
+			codes, err := validate.CountryCodes(country)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Country %s unblocked\n", country)
+			client := api.NewClient()
+			for _, code := range codes {
+				_, err := client.Post(api.Endpoint("/v1/cdn/ng/domains/%d/firewall", domainID), map[string]interface{}{
+					"country": code,
+					"action":  "remove",
+				})
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Country %s unblocked\n", code)
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVar(&domainID, "domain", 0, "Domain ID")
-	cmd.Flags().StringVar(&country, "country", "", "Country code")
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&country, "country", "", "ISO country code, or a comma-separated list")
 
 	cmd.MarkFlagRequired("domain")
 	cmd.MarkFlagRequired("country")
@@ -0,0 +1,274 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+)
+
+// WAFConfig is a domain's complete WAF setup as written by waf export.
+// Layers and groups map IDs to whether they are enabled. Enabled and
+// DisabledRules are pointers so an import file that leaves them out
+// leaves those settings alone rather than clearing them.
+type WAFConfig struct {
+	Enabled       *bool           `json:"enabled,omitempty"`
+	Mode          string          `json:"mode,omitempty"`
+	Layers        map[string]bool `json:"layers,omitempty"`
+	Groups        map[string]bool `json:"groups,omitempty"`
+	DisabledRules *[]string       `json:"disabled_rules,omitempty"`
+}
+
+func newWAFExportCmd() *cobra.Command {
+	var domainID int
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a domain's WAF configuration as JSON",
+		Long:  "Capture whether the WAF is on, its mode, which layers and groups are enabled and which rules are disabled, in a form waf import accepts.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			cfg, err := getWAFConfig(client, domainID)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(cfg, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+
+			if outputFile == "" {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+			if err := os.WriteFile(outputFile, data, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputFile, err)
+			}
+			fmt.Printf("WAF configuration exported to %s\n", outputFile)
+			return nil
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the export to a file instead of stdout")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func newWAFImportCmd() *cobra.Command {
+	var domainID int
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Apply a WAF configuration written by waf export",
+		Long: `Make a domain's WAF match a file written by waf export: the WAF is turned
+on or off and its mode set, listed groups are switched on or off, and rules
+are enabled or disabled so that exactly the file's disabled_rules are
+disabled. Keys missing from the file, and groups it doesn't list, are left
+alone. Layers can't be switched through the API, so layer differences are
+only reported as warnings.
+
+Only the switches that differ are sent. With --dry-run the plan is printed
+without changing anything.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var desired WAFConfig
+			if err := json.Unmarshal(data, &desired); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if desired.Mode != "" {
+				if err := validateChoice("mode", desired.Mode, "block", "simulate"); err != nil {
+					return fmt.Errorf("%s: %w", file, err)
+				}
+			}
+
+			client := api.NewClient()
+			current, err := getWAFConfig(client, domainID)
+			if err != nil {
+				return err
+			}
+
+			changes, warnings := planWAFChanges(domainID, current, &desired)
+			for _, w := range warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+			}
+			return applyConfigChanges(changes, client.Put, "the WAF", file)
+		},
+	}
+
+	addDomainFlag(cmd, &domainID)
+	cmd.Flags().StringVar(&file, "file", "", "JSON file written by waf export")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// getWAFConfig collects a domain's WAF status, layers, groups and disabled
+// rules
+func getWAFConfig(client *api.Client, domainID int) (*WAFConfig, error) {
+	disabled := []string{}
+	cfg := &WAFConfig{Enabled: new(bool), Layers: map[string]bool{}, Groups: map[string]bool{}, DisabledRules: &disabled}
+
+	results, err := client.ParallelGet([]string{
+		api.Endpoint("/v1/cdn/ng/domains/%d/waf", domainID),
+		api.Endpoint("/v1/cdn/ng/domains/%d/waf/layers", domainID),
+		api.Endpoint("/v1/cdn/ng/domains/%d/waf/groups", domainID),
+		api.Endpoint("/v1/cdn/ng/domains/%d/waf/disabled-rules", domainID),
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(results[0].Response.Data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse status: %w", err)
+	}
+
+	var layers []WAFLayer
+	if err := json.Unmarshal(results[1].Response.Data, &layers); err != nil {
+		return nil, fmt.Errorf("failed to parse layers: %w", err)
+	}
+	for _, l := range layers {
+		cfg.Layers[l.ID] = l.Enabled
+	}
+
+	var groups []WAFGroup
+	if err := json.Unmarshal(results[2].Response.Data, &groups); err != nil {
+		return nil, fmt.Errorf("failed to parse groups: %w", err)
+	}
+	for _, g := range groups {
+		cfg.Groups[g.ID] = g.Enabled
+	}
+
+	var rules []WAFRule
+	if err := json.Unmarshal(results[3].Response.Data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	for _, r := range rules {
+		disabled = append(disabled, r.ID)
+	}
+	sort.Strings(disabled)
+	cfg.DisabledRules = &disabled
+
+	return cfg, nil
+}
+
+// planWAFChanges lists the switch calls that turn current into desired.
+// Sections whose key is missing from desired are skipped. Layer
+// differences can't be applied and come back as warnings instead.
+func planWAFChanges(domainID int, current, desired *WAFConfig) (changes []configChange, warnings []string) {
+	currentEnabled := current.Enabled != nil && *current.Enabled
+	enabled := currentEnabled
+	if desired.Enabled != nil {
+		enabled = *desired.Enabled
+	}
+	mode := desired.Mode
+	if mode == "" {
+		mode = current.Mode
+	}
+	if enabled != currentEnabled || (enabled && mode != current.Mode) {
+		c := configChange{endpoint: api.Endpoint("/v1/cdn/ng/domains/%d/waf", domainID)}
+		switch {
+		case enabled && currentEnabled:
+			c.description = fmt.Sprintf("switch the WAF to %s mode", mode)
+			c.body = map[string]interface{}{"enabled": true, "mode": mode}
+		case enabled:
+			c.description = fmt.Sprintf("enable the WAF (mode: %s)", mode)
+			c.body = map[string]interface{}{"enabled": true, "mode": mode}
+		default:
+			c.description = "disable the WAF"
+			c.body = map[string]interface{}{"enabled": false}
+		}
+		changes = append(changes, c)
+	}
+
+	for _, id := range sortedKeys(desired.Layers) {
+		have, ok := current.Layers[id]
+		switch {
+		case !ok:
+			warnings = append(warnings, fmt.Sprintf("layer %s does not exist on this domain; skipped", id))
+		case have != desired.Layers[id]:
+			warnings = append(warnings, fmt.Sprintf("layer %s is %s here but %s in the file; layers can't be switched from the CLI, change it in the dashboard", id, enabledWord(have), enabledWord(desired.Layers[id])))
+		}
+	}
+
+	for _, id := range sortedKeys(desired.Groups) {
+		if enabled, ok := current.Groups[id]; ok && enabled == desired.Groups[id] {
+			continue
+		}
+		changes = append(changes, configChange{
+			description: fmt.Sprintf("%s group %s", enableVerb(desired.Groups[id]), id),
+			endpoint:    api.Endpoint("/v1/cdn/ng/domains/%d/waf/switch-group", domainID),
+			body:        map[string]interface{}{"group_id": id, "enabled": desired.Groups[id]},
+		})
+	}
+
+	if desired.DisabledRules == nil {
+		return changes, warnings
+	}
+	wantDisabled := map[string]bool{}
+	for _, id := range *desired.DisabledRules {
+		wantDisabled[id] = true
+	}
+	isDisabled := map[string]bool{}
+	if current.DisabledRules != nil {
+		for _, id := range *current.DisabledRules {
+			isDisabled[id] = true
+		}
+	}
+	for _, id := range sortedKeys(isDisabled) {
+		if !wantDisabled[id] {
+			changes = append(changes, ruleSwitch(domainID, id, true))
+		}
+	}
+	for _, id := range sortedKeys(wantDisabled) {
+		if !isDisabled[id] {
+			changes = append(changes, ruleSwitch(domainID, id, false))
+		}
+	}
+
+	return changes, warnings
+}
+
+func ruleSwitch(domainID int, ruleID string, enabled bool) configChange {
+	return configChange{
+		description: fmt.Sprintf("%s rule %s", enableVerb(enabled), ruleID),
+		endpoint:    api.Endpoint("/v1/cdn/ng/domains/%d/waf/switch-rule", domainID),
+		body:        map[string]interface{}{"rule_id": ruleID, "enabled": enabled},
+	}
+}
+
+func enabledWord(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func enableVerb(enabled bool) string {
+	if enabled {
+		return "enable"
+	}
+	return "disable"
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
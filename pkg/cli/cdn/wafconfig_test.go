@@ -0,0 +1,72 @@
+package cdn
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPlanWAFChanges(t *testing.T) {
+	current := func() *WAFConfig {
+		enabled := true
+		disabled := []string{"941100", "942100"}
+		return &WAFConfig{
+			Enabled:       &enabled,
+			Mode:          "block",
+			Layers:        map[string]bool{"owasp": true},
+			Groups:        map[string]bool{"sqli": true, "xss": false},
+			DisabledRules: &disabled,
+		}
+	}
+	descriptions := func(changes []configChange) []string {
+		var out []string
+		for _, c := range changes {
+			out = append(out, c.description)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		file         string
+		wantChanges  []string
+		wantWarnings int
+	}{
+		{"empty file changes nothing", `{}`, nil, 0},
+		{"only groups", `{"groups":{"xss":true}}`, []string{"enable group xss"}, 0},
+		{"disable", `{"enabled":false}`, []string{"disable the WAF"}, 0},
+		{"mode without enabled", `{"mode":"simulate"}`, []string{"switch the WAF to simulate mode"}, 0},
+		{"empty disabled_rules re-enables", `{"disabled_rules":[]}`, []string{"enable rule 941100", "enable rule 942100"}, 0},
+		{"disabled_rules swapped", `{"disabled_rules":["942100","920100"]}`, []string{"enable rule 941100", "disable rule 920100"}, 0},
+		{"layer difference only warns", `{"layers":{"owasp":false,"missing":true}}`, nil, 2},
+		{"matching layer", `{"layers":{"owasp":true}}`, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var desired WAFConfig
+			if err := json.Unmarshal([]byte(tt.file), &desired); err != nil {
+				t.Fatal(err)
+			}
+			changes, warnings := planWAFChanges(1, current(), &desired)
+			if got := descriptions(changes); !reflect.DeepEqual(got, tt.wantChanges) {
+				t.Errorf("changes = %q, want %q", got, tt.wantChanges)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %q, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestWAFConfigExportRoundTrip(t *testing.T) {
+	disabled := []string{}
+	enabled := false
+	data, err := json.Marshal(&WAFConfig{Enabled: &enabled, DisabledRules: &disabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"enabled":false,"disabled_rules":[]}` {
+		t.Errorf("export = %s, want enabled and disabled_rules kept", data)
+	}
+}
@@ -1,30 +1,126 @@
 package cli
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
+	"github.com/mizbancloud/cli/pkg/api"
 	"github.com/mizbancloud/cli/pkg/cli/auth"
 	"github.com/mizbancloud/cli/pkg/cli/cdn"
 	"github.com/mizbancloud/cli/pkg/cli/cloud"
 	"github.com/mizbancloud/cli/pkg/cli/ticket"
 	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 func NewRootCmd() *cobra.Command {
+	var (
+		boolStyle  string
+		assumeYes  bool
+		dryRun     bool
+		debug      bool
+		quiet      bool
+		noColor    bool
+		cfgPath    string
+		baseURL    string
+		insecure   bool
+		maxRetries int
+		fields     string
+		template   string
+		timeout    time.Duration
+		cancel     context.CancelFunc
+	)
+
 	rootCmd := &cobra.Command{
 		Use:     "mizban",
 		Short:   "MizbanCloud CLI - Manage your cloud infrastructure",
 		Long:    "MizbanCloud CLI is a command-line tool for managing MizbanCloud services including Cloud (IaaS), CDN, and Support.",
 		Version: config.Version,
+		// main prints errors itself; usage is only useful for flag errors
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if cfgPath != "" {
+				config.SetPath(cfgPath)
+			}
+			prompt.SetAssumeYes(assumeYes)
+			output.SetQuiet(quiet)
+			output.SetColor(!noColor)
+			if err := output.SetFormat(fields, template); err != nil {
+				return err
+			}
+			if output.Formatting() {
+				// --fields and --template reshape JSON output, so they
+				// switch it on for any command that has --json
+				if cmd.Flags().Lookup("json") == nil {
+					return fmt.Errorf("--fields and --template only work with commands that support --json")
+				}
+				cmd.Flags().Set("json", "true")
+			}
+			// Progress bars would only get in the way of machine-readable output
+			if f := cmd.Flags().Lookup("json"); f != nil && f.Value.String() == "true" {
+				output.SetProgress(false)
+			}
+			config.GetConfig().DryRun = dryRun
+			config.GetConfig().Debug = debug
+			config.GetConfig().SkipTLSVerify = insecure
+			if baseURL != "" {
+				if err := config.GetConfig().OverrideBaseURL(baseURL); err != nil {
+					return err
+				}
+			}
+			if maxRetries < 0 {
+				return fmt.Errorf("invalid --max-retries %d: must be 0 or more", maxRetries)
+			}
+			config.GetConfig().MaxRetries = maxRetries
+
+			ctx := cmd.Context()
+			if timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			api.SetContext(ctx)
+
+			if err := types.SetBoolStyle(boolStyle); err != nil {
+				return err
+			}
+			return validate.PositiveIDFlags(cmd)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if cancel != nil {
+				cancel()
+			}
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Help()
 		},
 	}
 
+	rootCmd.PersistentFlags().StringVar(&cfgPath, "config", "", "Config file to use (default $MIZBAN_CONFIG or ~/.mizbancloud/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&baseURL, "base-url", "", "API base URL for this command only, e.g. a staging gateway (not saved; overrides $MIZBAN_API_URL)")
+	rootCmd.PersistentFlags().StringVar(&boolStyle, "bool-style", types.BoolStyleYesNo, "How to display booleans (true-false/yes-no/on-off)")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Skip all confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print write requests (method, URL, body) instead of sending them")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort the command if it takes longer than this (e.g. 30s, 2m; 0 means no limit)")
+	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "Skip TLS certificate verification (for self-signed gateways)")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", config.DefaultMaxRetries, "Retry rate-limited requests up to this many times, waiting as the API's Retry-After asks (0 disables)")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Log HTTP requests and responses to stderr")
+	rootCmd.PersistentFlags().StringVar(&fields, "fields", "", "Print only these JSON fields, tab-separated (e.g. id,public_ip; dots for nested fields)")
+	rootCmd.PersistentFlags().StringVar(&template, "template", "", "Render JSON output with a Go template, once per list item (e.g. '{{.id}} {{.name}}')")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also off when NO_COLOR is set or stdout is not a terminal)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Print only the IDs of created resources to stdout; informational messages go to stderr")
+
 	// Auth commands
 	rootCmd.AddCommand(auth.NewLoginCmd())
 	rootCmd.AddCommand(auth.NewLogoutCmd())
+	rootCmd.AddCommand(auth.NewWhoamiCmd())
 	rootCmd.AddCommand(auth.NewProfileCmd())
+	rootCmd.AddCommand(auth.NewConfigCmd())
 
 	// Cloud commands
 	rootCmd.AddCommand(cloud.NewServerCmd())
@@ -33,6 +129,7 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(cloud.NewSSHCmd())
 	rootCmd.AddCommand(cloud.NewFirewallCmd())
 	rootCmd.AddCommand(cloud.NewNetworkCmd())
+	rootCmd.AddCommand(cloud.NewDatacenterCmd())
 
 	// CDN commands
 	rootCmd.AddCommand(cdn.NewDomainCmd())
@@ -48,9 +145,12 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(cdn.NewPageRulesCmd())
 	rootCmd.AddCommand(cdn.NewLogForwarderCmd())
 	rootCmd.AddCommand(cdn.NewPlansCmd())
+	rootCmd.AddCommand(cdn.NewCDNCmd())
 
 	// Ticket commands
 	rootCmd.AddCommand(ticket.NewTicketCmd())
 
+	rootCmd.AddCommand(newVersionCmd())
+
 	return rootCmd
 }
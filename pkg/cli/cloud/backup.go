@@ -0,0 +1,189 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/types"
+)
+
+// Backup is one scheduled backup of a server. Unlike snapshots, backups
+// are taken on a schedule and expire after the retention period.
+type Backup struct {
+	ID        int             `json:"id"`
+	Size      int             `json:"size"`
+	Status    string          `json:"status"`
+	Type      string          `json:"type"`
+	CreatedAt types.Timestamp `json:"created_at"`
+	ExpiresAt types.Timestamp `json:"expires_at"`
+}
+
+func newServerBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backup",
+		Aliases: []string{"backups"},
+		Short:   "Manage scheduled server backups",
+		Long:    "Schedule automatic backups of a server and restore from them. Backups are kept for the retention period and then removed; for one-off copies use 'mizban snapshot'.",
+	}
+
+	cmd.AddCommand(newServerBackupEnableCmd())
+	cmd.AddCommand(newServerBackupDisableCmd())
+	cmd.AddCommand(newServerBackupListCmd())
+	cmd.AddCommand(newServerBackupRestoreCmd())
+
+	return cmd
+}
+
+func newServerBackupEnableCmd() *cobra.Command {
+	var schedule string
+	var retention int
+
+	cmd := &cobra.Command{
+		Use:   "enable [server-id]",
+		Short: "Turn on scheduled backups",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if schedule != "daily" && schedule != "weekly" {
+				return fmt.Errorf("invalid --schedule %q: must be daily or weekly", schedule)
+			}
+			if retention < 1 {
+				return fmt.Errorf("invalid --retention %d: must be a positive number", retention)
+			}
+
+			client := api.NewClient()
+			_, err := client.Post(api.Endpoint("/v1/cloud/servers/%s/backups/enable", args[0]), map[string]interface{}{
+				"schedule":  schedule,
+				"retention": retention,
+			})
+			if err != nil {
+				return err
+			}
+
+			output.Infof("Backups enabled for server %s: %s, keeping the last %d\n", args[0], schedule, retention)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&schedule, "schedule", "daily", "How often to back up (daily/weekly)")
+	cmd.Flags().IntVar(&retention, "retention", 7, "Number of backups to keep")
+
+	return cmd
+}
+
+func newServerBackupDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable [server-id]",
+		Short: "Turn off scheduled backups",
+		Long:  "Stop taking new backups. Existing backups are kept until they expire.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			_, err := client.Post(api.Endpoint("/v1/cloud/servers/%s/backups/disable", args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			output.Infof("Backups disabled for server %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newServerBackupListCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list [server-id]",
+		Short: "List a server's backups",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cloud/servers/%s/backups", args[0]))
+			if err != nil {
+				return err
+			}
+
+			var backups []Backup
+			if err := json.Unmarshal(resp.Data, &backups); err != nil {
+				return fmt.Errorf("failed to parse backups: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(backups)
+			}
+
+			if len(backups) == 0 {
+				fmt.Println("No backups found")
+				return nil
+			}
+
+			table := output.NewTable("ID", "TYPE", "SIZE(GB)", "STATUS", "CREATED", "EXPIRES")
+			for _, b := range backups {
+				table.AddRow(b.ID, b.Type, b.Size, b.Status, b.CreatedAt, b.ExpiresAt)
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newServerBackupRestoreCmd() *cobra.Command {
+	var backupID int
+	var force, wait bool
+	var waitTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "restore [server-id]",
+		Short: "Restore a server from a backup",
+		Long:  "Replace the server's disk with the contents of a backup. Anything written since the backup was taken is lost, so you are asked to confirm unless --force is given.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !force && !config.GetConfig().DryRun {
+				ok, err := prompt.Confirm(fmt.Sprintf("Restore server %s from backup %d? Its current disk will be overwritten.", args[0], backupID))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
+				}
+			}
+
+			client := api.NewClient()
+			_, err := client.Post(api.Endpoint("/v1/cloud/servers/%s/backups/%d/restore", args[0], backupID), nil)
+			if err != nil {
+				return err
+			}
+
+			output.Infoln("Server restore initiated")
+			if !wait {
+				return nil
+			}
+
+			if _, err := waitForServerStatus(client, args[0], "running", waitTimeout, 5*time.Second); err != nil {
+				return err
+			}
+			fmt.Printf("Server %s is running again\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&backupID, "backup", 0, "Backup ID to restore (see 'mizban server backup list')")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the server is running again")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait with --wait (0 waits forever)")
+	cmd.MarkFlagRequired("backup")
+
+	return cmd
+}
@@ -0,0 +1,180 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/completion"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/types"
+)
+
+type Datacenter struct {
+	ID        int               `json:"id"`
+	Name      string            `json:"name"`
+	Location  string            `json:"location"`
+	Country   string            `json:"country,omitempty"`
+	Available types.NumericBool `json:"available"`
+}
+
+type OperatingSystem struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Family  string `json:"family,omitempty"`
+}
+
+func NewDatacenterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "datacenter",
+		Aliases: []string{"datacenters", "dc"},
+		Short:   "List cloud datacenters",
+		Long:    "Look up the datacenter IDs accepted by --datacenter when creating servers, volumes and networks.",
+	}
+
+	cmd.AddCommand(newDatacenterListCmd())
+
+	return cmd
+}
+
+func newDatacenterListCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available datacenters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			datacenters, err := getDatacenters(client)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(datacenters)
+			}
+
+			if len(datacenters) == 0 {
+				fmt.Println("No datacenters found")
+				return nil
+			}
+
+			table := output.NewTable("ID", "NAME", "LOCATION", "AVAILABLE")
+			for _, dc := range datacenters {
+				location := dc.Location
+				if dc.Country != "" {
+					location = fmt.Sprintf("%s (%s)", location, dc.Country)
+				}
+				table.AddRow(dc.ID, dc.Name, location, dc.Available)
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newServerOSListCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "os-list",
+		Aliases: []string{"images"},
+		Short:   "List operating systems available for servers",
+		Long:    "List the operating system slugs accepted by --os in server create and server rebuild.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			systems, err := getOperatingSystems(client)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(systems)
+			}
+
+			if len(systems) == 0 {
+				fmt.Println("No operating systems found")
+				return nil
+			}
+
+			table := output.NewTable("SLUG", "NAME", "VERSION", "FAMILY")
+			for _, os := range systems {
+				table.AddRow(os.Slug, os.Name, os.Version, os.Family)
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func getDatacenters(client *api.Client) ([]Datacenter, error) {
+	resp, err := client.Get("/v1/cloud/datacenters")
+	if err != nil {
+		return nil, err
+	}
+
+	var datacenters []Datacenter
+	if err := json.Unmarshal(resp.Data, &datacenters); err != nil {
+		return nil, fmt.Errorf("failed to parse datacenters: %w", err)
+	}
+	return datacenters, nil
+}
+
+func getOperatingSystems(client *api.Client) ([]OperatingSystem, error) {
+	resp, err := client.Get("/v1/cloud/operating-systems")
+	if err != nil {
+		return nil, err
+	}
+
+	var systems []OperatingSystem
+	if err := json.Unmarshal(resp.Data, &systems); err != nil {
+		return nil, fmt.Errorf("failed to parse operating systems: %w", err)
+	}
+	return systems, nil
+}
+
+// completeDatacenters offers datacenter IDs, described by name, for shell completion
+func completeDatacenters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries := completion.Cached("datacenters", func(ctx context.Context) ([]string, error) {
+		datacenters, err := getDatacenters(api.NewClient().WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]string, 0, len(datacenters))
+		for _, dc := range datacenters {
+			entries = append(entries, fmt.Sprintf("%d\t%s", dc.ID, dc.Name))
+		}
+		return entries, nil
+	})
+	return entries, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeOperatingSystems offers OS slugs for shell completion
+func completeOperatingSystems(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries := completion.Cached("operating-systems", func(ctx context.Context) ([]string, error) {
+		systems, err := getOperatingSystems(api.NewClient().WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]string, 0, len(systems))
+		for _, os := range systems {
+			entries = append(entries, fmt.Sprintf("%s\t%s", os.Slug, os.Name))
+		}
+		return entries, nil
+	})
+	return entries, cobra.ShellCompDirectiveNoFileComp
+}
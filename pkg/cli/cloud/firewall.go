@@ -3,11 +3,14 @@ package cloud
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 type Firewall struct {
@@ -27,6 +30,18 @@ type FirewallRule struct {
 	RemoteIP  string `json:"remote_ip"`
 }
 
+// PortRange renders the rule's ports as "80", "8000-9000" or "all"
+func (r FirewallRule) PortRange() string {
+	switch {
+	case r.PortMin == 0 && r.PortMax == 0:
+		return "all"
+	case r.PortMax == 0 || r.PortMax == r.PortMin:
+		return strconv.Itoa(r.PortMin)
+	default:
+		return fmt.Sprintf("%d-%d", r.PortMin, r.PortMax)
+	}
+}
+
 func NewFirewallCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "firewall",
@@ -64,9 +79,7 @@ func newFirewallListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(firewalls, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(firewalls)
 			}
 
 			if len(firewalls) == 0 {
@@ -74,11 +87,11 @@ func newFirewallListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-25s %-10s %-10s\n", "ID", "NAME", "RULES", "SERVERS")
-			fmt.Println(strings.Repeat("-", 55))
+			table := output.NewTable("ID", "NAME", "RULES", "SERVERS")
 			for _, f := range firewalls {
-				fmt.Printf("%-6d %-25s %-10d %-10d\n", f.ID, truncate(f.Name, 25), len(f.Rules), len(f.Servers))
+				table.AddRow(f.ID, f.Name, len(f.Rules), len(f.Servers))
 			}
+			table.Render()
 
 			return nil
 		},
@@ -108,9 +121,10 @@ func newFirewallCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse firewall: %w", err)
 			}
 
-			fmt.Printf("Firewall created successfully!\n")
-			fmt.Printf("ID: %d\n", firewall.ID)
-			fmt.Printf("Name: %s\n", firewall.Name)
+			output.Infof("Firewall created successfully!\n")
+			output.Infof("ID: %d\n", firewall.ID)
+			output.PrintID(firewall.ID)
+			output.Infof("Name: %s\n", firewall.Name)
 
 			return nil
 		},
@@ -131,22 +145,26 @@ func newFirewallDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete firewall %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete firewall %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/firewall/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/firewall/%s", args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("Firewall already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Firewall deleted successfully")
+			output.Infoln("Firewall deleted successfully")
 			return nil
 		},
 	}
@@ -162,12 +180,56 @@ func newFirewallRuleCmd() *cobra.Command {
 		Short: "Manage firewall rules",
 	}
 
+	cmd.AddCommand(newFirewallRuleListCmd())
 	cmd.AddCommand(newFirewallRuleAddCmd())
 	cmd.AddCommand(newFirewallRuleDeleteCmd())
 
 	return cmd
 }
 
+func newFirewallRuleListCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list [firewall-id]",
+		Short: "List the rules of a firewall",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cloud/firewall/%s", args[0]))
+			if err != nil {
+				return err
+			}
+
+			var firewall Firewall
+			if err := json.Unmarshal(resp.Data, &firewall); err != nil {
+				return fmt.Errorf("failed to parse firewall: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(firewall.Rules)
+			}
+
+			if len(firewall.Rules) == 0 {
+				fmt.Println("No firewall rules found")
+				return nil
+			}
+
+			table := output.NewTable("ID", "DIRECTION", "PROTOCOL", "PORTS", "REMOTE IP")
+			for _, r := range firewall.Rules {
+				table.AddRow(r.ID, r.Direction, r.Protocol, r.PortRange(), r.RemoteIP)
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
 func newFirewallRuleAddCmd() *cobra.Command {
 	var firewallID int
 	var direction, protocol, remoteIP string
@@ -177,6 +239,10 @@ func newFirewallRuleAddCmd() *cobra.Command {
 		Use:   "add",
 		Short: "Add a firewall rule",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validate.IPOrCIDR(remoteIP); err != nil {
+				return fmt.Errorf("invalid --remote-ip: %w", err)
+			}
+
 			client := api.NewClient()
 
 			body := map[string]interface{}{
@@ -193,7 +259,7 @@ func newFirewallRuleAddCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Firewall rule added successfully")
+			output.Infoln("Firewall rule added successfully")
 			return nil
 		},
 	}
@@ -218,12 +284,16 @@ func newFirewallRuleDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/firewall/rule/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/firewall/rule/%s", args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("Firewall rule already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Firewall rule deleted successfully")
+			output.Infoln("Firewall rule deleted successfully")
 			return nil
 		},
 	}
@@ -246,7 +316,7 @@ func newFirewallAttachCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Firewall attached successfully")
+			output.Infoln("Firewall attached successfully")
 			return nil
 		},
 	}
@@ -274,7 +344,7 @@ func newFirewallDetachCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Firewall detached successfully")
+			output.Infoln("Firewall detached successfully")
 			return nil
 		},
 	}
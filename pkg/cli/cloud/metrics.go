@@ -0,0 +1,132 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/util"
+)
+
+const metricsBarWidth = 20
+
+func newServerMetricsCmd() *cobra.Command {
+	var follow, jsonOutput, si bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "metrics [server-id]",
+		Short: "Show a server's current CPU, RAM, disk and network usage",
+		Long: `Show the latest sample from the server's performance reports. With --follow,
+poll every --interval and redraw the figures in place, like top, until Ctrl-C.
+When stdout is not a terminal each poll is printed below the last instead.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if follow && jsonOutput {
+				return fmt.Errorf("--follow cannot be combined with --json")
+			}
+			if interval <= 0 {
+				return fmt.Errorf("invalid --interval %s: must be positive", interval)
+			}
+
+			units := util.IEC
+			if si {
+				units = util.SI
+			}
+			client := api.NewClient()
+
+			if !follow {
+				report, err := getLatestServerReport(client, args[0])
+				if err != nil {
+					return err
+				}
+				if jsonOutput {
+					return output.PrintJSONValue(report)
+				}
+				fmt.Print(formatServerMetrics(args[0], report, units))
+				return nil
+			}
+
+			// The API has no streaming endpoint, so follow mode polls
+			redraw := term.IsTerminal(int(os.Stdout.Fd()))
+			lines := 0
+			return util.Poll(client.Context(), 0, interval, func() (bool, error) {
+				report, err := getLatestServerReport(client, args[0])
+				if err != nil {
+					return false, err
+				}
+				block := formatServerMetrics(args[0], report, units) + fmt.Sprintf("\nUpdated %s, every %s (Ctrl-C to stop)\n", time.Now().Format("15:04:05"), interval)
+				if redraw && lines > 0 {
+					// Move back to the top of the last block and clear it
+					fmt.Printf("\x1b[%dA\x1b[J", lines)
+				} else if lines > 0 {
+					fmt.Println()
+				}
+				fmt.Print(block)
+				lines = strings.Count(block, "\n")
+				return false, nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep polling and redraw the metrics until Ctrl-C")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between polls in --follow mode")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the latest sample as JSON")
+	cmd.Flags().BoolVar(&si, "si", false, "Use decimal SI units (1 KB = 1000 B) instead of binary units")
+
+	return cmd
+}
+
+// getLatestServerReport returns the most recent bucket of the server's
+// hourly report, or nil when there is none yet
+func getLatestServerReport(client *api.Client, serverID string) (*ServerReport, error) {
+	endpoint := api.Endpoint("/v1/cloud/servers/%s/reports", serverID) + "?period=hour"
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []ServerReport
+	if err := json.Unmarshal(resp.Data, &reports); err != nil {
+		return nil, fmt.Errorf("failed to parse reports: %w", err)
+	}
+	if len(reports) == 0 {
+		return nil, nil
+	}
+	return &reports[len(reports)-1], nil
+}
+
+func formatServerMetrics(serverID string, r *ServerReport, units util.ByteUnits) string {
+	var b strings.Builder
+	if r == nil {
+		fmt.Fprintf(&b, "Server %s: no metrics reported yet\n", serverID)
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Server %s (sample %s)\n\n", serverID, r.Time)
+	rate := func(v float64) string { return util.FormatBytes(int64(v), units) + "/s" }
+	fmt.Fprintf(&b, "%-12s %6.1f%%  %s\n", "CPU", r.CPU, usageBar(r.CPU))
+	fmt.Fprintf(&b, "%-12s %6.1f%%  %s\n", "RAM", r.RAM, usageBar(r.RAM))
+	fmt.Fprintf(&b, "%-12s %s read, %s write\n", "Disk", rate(r.DiskRead), rate(r.DiskWrite))
+	fmt.Fprintf(&b, "%-12s %s in, %s out\n", "Network", rate(r.NetworkIn), rate(r.NetworkOut))
+	return b.String()
+}
+
+// usageBar draws a percentage as a fixed-width bar
+func usageBar(percent float64) string {
+	filled := int(percent / 100 * metricsBarWidth)
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > metricsBarWidth {
+		filled = metricsBarWidth
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(" ", metricsBarWidth-filled) + "]"
+}
@@ -3,11 +3,13 @@ package cloud
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/validate"
 )
 
 type PrivateNetwork struct {
@@ -28,6 +30,7 @@ func NewNetworkCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newNetworkListCmd())
+	cmd.AddCommand(newNetworkShowCmd())
 	cmd.AddCommand(newNetworkCreateCmd())
 	cmd.AddCommand(newNetworkDeleteCmd())
 	cmd.AddCommand(newNetworkAttachCmd())
@@ -55,9 +58,7 @@ func newNetworkListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(networks, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(networks)
 			}
 
 			if len(networks) == 0 {
@@ -65,11 +66,91 @@ func newNetworkListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-20s %-18s %-15s %-10s\n", "ID", "NAME", "CIDR", "GATEWAY", "SERVERS")
-			fmt.Println(strings.Repeat("-", 75))
+			table := output.NewTable("ID", "NAME", "CIDR", "GATEWAY", "SERVERS")
 			for _, n := range networks {
-				fmt.Printf("%-6d %-20s %-18s %-15s %-10d\n", n.ID, truncate(n.Name, 20), n.CIDR, n.Gateway, len(n.Servers))
+				table.AddRow(n.ID, n.Name, n.CIDR, n.Gateway, len(n.Servers))
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newNetworkShowCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "show [network-id]",
+		Aliases: []string{"list-servers"},
+		Short:   "Show a private network and its attached servers",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cloud/private-networks/%s", args[0]))
+			if err != nil {
+				return err
+			}
+
+			var network PrivateNetwork
+			if err := json.Unmarshal(resp.Data, &network); err != nil {
+				return fmt.Errorf("failed to parse network: %w", err)
+			}
+
+			endpoints := make([]string, len(network.Servers))
+			for i, id := range network.Servers {
+				endpoints[i] = api.Endpoint("/v1/cloud/servers/%d", id)
 			}
+			results, _ := client.ParallelGet(endpoints, 0)
+
+			servers := make([]Server, 0, len(network.Servers))
+			for i, id := range network.Servers {
+				if api.IsNotFound(results[i].Err) {
+					servers = append(servers, Server{ID: id})
+					continue
+				}
+				if results[i].Err != nil {
+					return fmt.Errorf("failed to look up server %d: %w", id, results[i].Err)
+				}
+				var server Server
+				if err := json.Unmarshal(results[i].Response.Data, &server); err != nil {
+					return fmt.Errorf("failed to parse server: %w", err)
+				}
+				servers = append(servers, server)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(struct {
+					PrivateNetwork
+					Servers []Server `json:"servers"`
+				}{network, servers})
+			}
+
+			fmt.Printf("ID:      %d\n", network.ID)
+			fmt.Printf("Name:    %s\n", network.Name)
+			fmt.Printf("CIDR:    %s\n", network.CIDR)
+			fmt.Printf("Gateway: %s\n", network.Gateway)
+			fmt.Printf("Created: %s\n", network.CreatedAt)
+
+			if len(servers) == 0 {
+				fmt.Println("\nNo servers attached")
+				return nil
+			}
+
+			fmt.Println()
+			table := output.NewTable("SERVER ID", "NAME", "STATUS", "PRIVATE IP")
+			for _, s := range servers {
+				name := s.Name
+				if name == "" {
+					name = "(not found)"
+				}
+				table.AddRow(s.ID, name, s.Status, s.PrivateIP)
+			}
+			table.Render()
 
 			return nil
 		},
@@ -106,11 +187,12 @@ func newNetworkCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse network: %w", err)
 			}
 
-			fmt.Printf("Private network created successfully!\n")
-			fmt.Printf("ID: %d\n", network.ID)
-			fmt.Printf("Name: %s\n", network.Name)
-			fmt.Printf("CIDR: %s\n", network.CIDR)
-			fmt.Printf("Gateway: %s\n", network.Gateway)
+			output.Infof("Private network created successfully!\n")
+			output.Infof("ID: %d\n", network.ID)
+			output.PrintID(network.ID)
+			output.Infof("Name: %s\n", network.Name)
+			output.Infof("CIDR: %s\n", network.CIDR)
+			output.Infof("Gateway: %s\n", network.Gateway)
 
 			return nil
 		},
@@ -118,7 +200,8 @@ func newNetworkCreateCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&name, "name", "", "Network name")
 	cmd.Flags().StringVar(&cidr, "cidr", "10.0.0.0/24", "Network CIDR (e.g., 10.0.0.0/24)")
-	cmd.Flags().IntVar(&datacenter, "datacenter", 1, "Datacenter ID")
+	cmd.Flags().IntVar(&datacenter, "datacenter", 1, "Datacenter ID (see 'mizban datacenter list')")
+	cmd.RegisterFlagCompletionFunc("datacenter", completeDatacenters)
 
 	cmd.MarkFlagRequired("name")
 
@@ -134,22 +217,26 @@ func newNetworkDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete network %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete network %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/private-networks/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/private-networks/%s", args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("Private network already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Private network deleted successfully")
+			output.Infoln("Private network deleted successfully")
 			return nil
 		},
 	}
@@ -168,6 +255,12 @@ func newNetworkAttachCmd() *cobra.Command {
 		Short: "Attach server to private network",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if ip != "" {
+				if err := validate.IP(ip); err != nil {
+					return err
+				}
+			}
+
 			client := api.NewClient()
 
 			body := map[string]interface{}{
@@ -183,12 +276,13 @@ func newNetworkAttachCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Server attached to network successfully")
+			output.Infoln("Server attached to network successfully")
 			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID")
+	cmd.RegisterFlagCompletionFunc("server", completeServers)
 	cmd.Flags().StringVar(&ip, "ip", "", "Specific IP address (optional)")
 
 	cmd.MarkFlagRequired("server")
@@ -213,12 +307,13 @@ func newNetworkDetachCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Server detached from network successfully")
+			output.Infoln("Server detached from network successfully")
 			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID")
+	cmd.RegisterFlagCompletionFunc("server", completeServers)
 	cmd.MarkFlagRequired("server")
 
 	return cmd
@@ -0,0 +1,95 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/completion"
+	"github.com/mizbancloud/cli/pkg/output"
+)
+
+// ServerPlan is a named server size that server create accepts via --plan
+type ServerPlan struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	CPU     int    `json:"cpu"`
+	RAM     int    `json:"ram"`
+	Storage int    `json:"storage"`
+	Price   int64  `json:"price"`
+}
+
+func newServerPlansCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "plans",
+		Aliases: []string{"sizes"},
+		Short:   "List server plans",
+		Long:    "List the named server sizes with their specs and monthly price. Pass a plan ID to server create --plan instead of --cpu, --ram and --storage.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			plans, err := getServerPlans(client)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(plans)
+			}
+
+			if len(plans) == 0 {
+				fmt.Println("No plans available")
+				return nil
+			}
+
+			table := output.NewTable("ID", "NAME", "CPU", "RAM (MB)", "STORAGE (GB)", "PRICE")
+			for _, p := range plans {
+				price := fmt.Sprintf("%d Toman/month", p.Price)
+				if p.Price == 0 {
+					price = "Free"
+				}
+				table.AddRow(p.ID, p.Name, p.CPU, p.RAM, p.Storage, price)
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func getServerPlans(client *api.Client) ([]ServerPlan, error) {
+	resp, err := client.Get("/v1/cloud/plans")
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []ServerPlan
+	if err := json.Unmarshal(resp.Data, &plans); err != nil {
+		return nil, fmt.Errorf("failed to parse plans: %w", err)
+	}
+	return plans, nil
+}
+
+// completeServerPlans offers plan IDs, described by name, for shell completion
+func completeServerPlans(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries := completion.Cached("server-plans", func(ctx context.Context) ([]string, error) {
+		plans, err := getServerPlans(api.NewClient().WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]string, 0, len(plans))
+		for _, p := range plans {
+			entries = append(entries, fmt.Sprintf("%d\t%s (%d CPU, %d MB, %d GB)", p.ID, p.Name, p.CPU, p.RAM, p.Storage))
+		}
+		return entries, nil
+	})
+	return entries, cobra.ShellCompDirectiveNoFileComp
+}
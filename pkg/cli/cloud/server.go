@@ -1,13 +1,26 @@
 package cloud
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/completion"
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type Server struct {
@@ -41,47 +54,98 @@ func NewServerCmd() *cobra.Command {
 	cmd.AddCommand(newServerVNCCmd())
 	cmd.AddCommand(newServerLogsCmd())
 	cmd.AddCommand(newServerRebuildCmd())
+	cmd.AddCommand(newServerResizeCmd())
+	cmd.AddCommand(newServerOSListCmd())
+	cmd.AddCommand(newServerPlansCmd())
 	cmd.AddCommand(newServerReportsCmd())
+	cmd.AddCommand(newServerMetricsCmd())
 	cmd.AddCommand(newServerRescueCmd())
+	cmd.AddCommand(newServerWaitCmd())
+	cmd.AddCommand(newServerSSHCmd())
+	cmd.AddCommand(newServerBackupCmd())
+
+	setServerArgCompletion(cmd)
 
 	return cmd
 }
 
+// setServerArgCompletion makes every subcommand that takes a [server-id]
+// argument complete it from the API
+func setServerArgCompletion(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		if strings.Contains(sub.Use, "[server-id]") && sub.ValidArgsFunction == nil {
+			sub.ValidArgsFunction = completeServerArg
+		}
+		setServerArgCompletion(sub)
+	}
+}
+
+// completeServers offers server IDs, described by name, for shell completion
+func completeServers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries := completion.Cached("servers", func(ctx context.Context) ([]string, error) {
+		client := api.NewClient().WithContext(ctx)
+		servers, _, err := api.GetList[Server](client, "/v1/cloud/servers", api.PageOptions{All: true})
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]string, 0, len(servers))
+		for _, s := range servers {
+			entries = append(entries, fmt.Sprintf("%d\t%s", s.ID, s.Name))
+		}
+		return entries, nil
+	})
+	return entries, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeServerArg completes the single positional server argument
+func completeServerArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeServers(cmd, args, toComplete)
+}
+
 func newServerListCmd() *cobra.Command {
 	var jsonOutput bool
+	var pageOpts api.PageOptions
+	var filter serverFilter
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all servers",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/servers")
+			servers, meta, err := api.GetList[Server](client, "/v1/cloud/servers", pageOpts)
 			if err != nil {
 				return err
 			}
-
-			var servers []Server
-			if err := json.Unmarshal(resp.Data, &servers); err != nil {
-				return fmt.Errorf("failed to parse servers: %w", err)
-			}
+			fetched := len(servers)
+			servers = filter.apply(servers)
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(servers, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(servers)
 			}
 
 			if len(servers) == 0 {
-				fmt.Println("No servers found")
+				if filter.active() && fetched > 0 {
+					fmt.Println("No servers match the filters")
+				} else {
+					fmt.Println("No servers found")
+				}
 				return nil
 			}
 
-			fmt.Printf("%-6s %-20s %-12s %-6s %-8s %-18s %-12s\n",
-				"ID", "NAME", "STATUS", "CPU", "RAM", "IP", "OS")
-			fmt.Println(strings.Repeat("-", 90))
+			table := output.NewTable("ID", "NAME", "STATUS", "CPU", "RAM", "IP", "OS")
 			for _, s := range servers {
-				fmt.Printf("%-6d %-20s %-12s %-6d %-8d %-18s %-12s\n",
-					s.ID, truncate(s.Name, 20), s.Status, s.CPU, s.RAM, s.PublicIP, truncate(s.OS, 12))
+				table.AddRow(s.ID, s.Name, s.Status, s.CPU, s.RAM, s.PublicIP, s.OS)
+			}
+			table.Render()
+			if meta != nil {
+				footer := meta.Footer(fetched)
+				if filter.active() {
+					footer = fmt.Sprintf("%d matching, %s", len(servers), strings.ToLower(footer[:1])+footer[1:])
+				}
+				fmt.Printf("\n%s\n", footer)
 			}
 
 			return nil
@@ -89,32 +153,95 @@ func newServerListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().StringVar(&filter.status, "status", "", "Only show servers with this status (e.g. running, stopped)")
+	cmd.Flags().StringVar(&filter.nameContains, "name-contains", "", "Only show servers whose name contains this text (case-insensitive)")
+	cmd.Flags().IntVar(&filter.datacenter, "datacenter", 0, "Only show servers in this datacenter ID")
+	cmd.Flags().IntVar(&pageOpts.Page, "page", 0, "Page number to fetch")
+	cmd.Flags().IntVar(&pageOpts.PerPage, "per-page", 0, "Number of items per page")
+	cmd.Flags().BoolVar(&pageOpts.All, "all", false, "Fetch every page")
 
 	return cmd
 }
 
+// serverFilter narrows server list results on the client side
+type serverFilter struct {
+	status       string
+	nameContains string
+	datacenter   int
+}
+
+func (f serverFilter) active() bool {
+	return f.status != "" || f.nameContains != "" || f.datacenter > 0
+}
+
+func (f serverFilter) apply(servers []Server) []Server {
+	if !f.active() {
+		return servers
+	}
+	filtered := []Server{}
+	for _, s := range servers {
+		if f.status != "" && !strings.EqualFold(s.Status, f.status) {
+			continue
+		}
+		if f.nameContains != "" && !strings.Contains(strings.ToLower(s.Name), strings.ToLower(f.nameContains)) {
+			continue
+		}
+		if f.datacenter > 0 && s.DatacenterID != f.datacenter {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
+
 func newServerCreateCmd() *cobra.Command {
 	var name, os string
 	var cpu, ram, storage, datacenter int
-	var sshKeyID int
+	var sshKeyID, planID int
+	var sshKeyIDs []int
+	var userDataFile string
+	var wait bool
+	var waitTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new server",
+		Long: `Create a new server, sized either by a named plan (--plan, see 'mizban server plans') or by --cpu, --ram and --storage.
+
+Install several SSH keys with --ssh-keys, and provision the server on first
+boot by passing a cloud-init file (#cloud-config or a shell script) with --user-data.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var userData string
+			if userDataFile != "" {
+				var err error
+				if userData, err = readUserData(userDataFile); err != nil {
+					return err
+				}
+			}
+
 			client := api.NewClient()
 
 			body := map[string]interface{}{
 				"name":          name,
 				"os":            os,
-				"cpu":           cpu,
-				"ram":           ram,
-				"storage":       storage,
 				"datacenter_id": datacenter,
 			}
+			if planID > 0 {
+				body["plan_id"] = planID
+			} else {
+				body["cpu"] = cpu
+				body["ram"] = ram
+				body["storage"] = storage
+			}
 			if sshKeyID > 0 {
 				body["ssh_key_id"] = sshKeyID
 			}
+			if len(sshKeyIDs) > 0 {
+				body["ssh_key_ids"] = sshKeyIDs
+			}
+			if userData != "" {
+				body["user_data"] = userData
+			}
 
 			resp, err := client.Post("/v1/cloud/servers", body)
 			if err != nil {
@@ -126,29 +253,76 @@ func newServerCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse server: %w", err)
 			}
 
-			fmt.Printf("Server created successfully!\n")
-			fmt.Printf("ID: %d\n", server.ID)
-			fmt.Printf("Name: %s\n", server.Name)
-			fmt.Printf("Status: %s\n", server.Status)
+			output.Infof("Server created successfully!\n")
+			output.Infof("ID: %d\n", server.ID)
+			output.PrintID(server.ID)
+			output.Infof("Name: %s\n", server.Name)
+			output.Infof("Status: %s\n", server.Status)
+
+			if !wait {
+				return nil
+			}
+
+			running, err := waitForServerStatus(client, strconv.Itoa(server.ID), "running", waitTimeout, 5*time.Second)
+			if err != nil {
+				return err
+			}
 
+			output.Infoln()
+			printServerDetails(output.Info, running)
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Server name")
-	cmd.Flags().StringVar(&os, "os", "", "Operating system (e.g., ubuntu-22.04)")
+	cmd.Flags().StringVar(&os, "os", "", "Operating system (e.g., ubuntu-22.04; see 'mizban server os-list')")
+	cmd.RegisterFlagCompletionFunc("os", completeOperatingSystems)
 	cmd.Flags().IntVar(&cpu, "cpu", 1, "Number of CPU cores")
 	cmd.Flags().IntVar(&ram, "ram", 1024, "RAM in MB")
 	cmd.Flags().IntVar(&storage, "storage", 20, "Storage in GB")
-	cmd.Flags().IntVar(&datacenter, "datacenter", 1, "Datacenter ID")
+	cmd.Flags().IntVar(&planID, "plan", 0, "Server plan ID instead of --cpu/--ram/--storage (see 'mizban server plans')")
+	cmd.RegisterFlagCompletionFunc("plan", completeServerPlans)
+	cmd.Flags().IntVar(&datacenter, "datacenter", 1, "Datacenter ID (see 'mizban datacenter list')")
+	cmd.RegisterFlagCompletionFunc("datacenter", completeDatacenters)
 	cmd.Flags().IntVar(&sshKeyID, "ssh-key", 0, "SSH key ID")
+	cmd.Flags().IntSliceVar(&sshKeyIDs, "ssh-keys", nil, "SSH key IDs to install, comma-separated (see 'mizban ssh-key list')")
+	cmd.Flags().StringVar(&userDataFile, "user-data", "", "Cloud-init user-data file to run on first boot")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the server is running, then show its details")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait with --wait (0 waits forever)")
 
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("os")
+	cmd.MarkFlagsMutuallyExclusive("plan", "cpu")
+	cmd.MarkFlagsMutuallyExclusive("plan", "ram")
+	cmd.MarkFlagsMutuallyExclusive("plan", "storage")
+	cmd.MarkFlagsMutuallyExclusive("ssh-key", "ssh-keys")
 
 	return cmd
 }
 
+// userDataMaxSize is the largest cloud-init user-data accepted on create
+const userDataMaxSize = 64 * 1024
+
+// readUserData loads a --user-data file, checking it's a size and format
+// cloud-init will accept
+func readUserData(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read user data: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return "", fmt.Errorf("user data file %s is empty", path)
+	}
+	if len(data) > userDataMaxSize {
+		return "", fmt.Errorf("user data file %s is %s; the limit is %s", path,
+			util.FormatBytes(int64(len(data)), util.IEC), util.FormatBytes(userDataMaxSize, util.IEC))
+	}
+	if !strings.HasPrefix(string(data), "#") {
+		fmt.Fprintf(os.Stderr, "Warning: %s doesn't start with #cloud-config or #!; cloud-init may ignore it\n", path)
+	}
+	return string(data), nil
+}
+
 func newServerGetCmd() *cobra.Command {
 	var jsonOutput bool
 
@@ -158,7 +332,7 @@ func newServerGetCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/servers/" + args[0])
+			resp, err := client.Get(api.Endpoint("/v1/cloud/servers/%s", args[0]))
 			if err != nil {
 				return err
 			}
@@ -169,22 +343,10 @@ func newServerGetCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(server, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(server)
 			}
 
-			fmt.Printf("ID:         %d\n", server.ID)
-			fmt.Printf("Name:       %s\n", server.Name)
-			fmt.Printf("Status:     %s\n", server.Status)
-			fmt.Printf("CPU:        %d cores\n", server.CPU)
-			fmt.Printf("RAM:        %d MB\n", server.RAM)
-			fmt.Printf("Storage:    %d GB\n", server.Storage)
-			fmt.Printf("OS:         %s\n", server.OS)
-			fmt.Printf("Public IP:  %s\n", server.PublicIP)
-			fmt.Printf("Private IP: %s\n", server.PrivateIP)
-			fmt.Printf("Created:    %s\n", server.CreatedAt)
-
+			printServerDetails(os.Stdout, &server)
 			return nil
 		},
 	}
@@ -203,22 +365,26 @@ func newServerDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete server %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete server %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/servers/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/servers/%s", args[0]))
+			if api.IsNotFound(err) {
+				output.Infoln("Server already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Server deleted successfully")
+			output.Infoln("Server deleted successfully")
 			return nil
 		},
 	}
@@ -240,11 +406,11 @@ func newServerPowerCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put("/v1/cloud/servers/"+args[0]+"/power/on", nil)
+			_, err := client.Put(api.Endpoint("/v1/cloud/servers/%s/power/on", args[0]), nil)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Server powering on...")
+			output.Infoln("Server powering on...")
 			return nil
 		},
 	})
@@ -255,11 +421,11 @@ func newServerPowerCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put("/v1/cloud/servers/"+args[0]+"/power/off", nil)
+			_, err := client.Put(api.Endpoint("/v1/cloud/servers/%s/power/off", args[0]), nil)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Server powering off...")
+			output.Infoln("Server powering off...")
 			return nil
 		},
 	})
@@ -270,11 +436,11 @@ func newServerPowerCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put("/v1/cloud/servers/"+args[0]+"/power/reboot", nil)
+			_, err := client.Put(api.Endpoint("/v1/cloud/servers/%s/power/reboot", args[0]), nil)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Server rebooting...")
+			output.Infoln("Server rebooting...")
 			return nil
 		},
 	})
@@ -285,11 +451,11 @@ func newServerPowerCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put("/v1/cloud/servers/"+args[0]+"/power/restart", nil)
+			_, err := client.Put(api.Endpoint("/v1/cloud/servers/%s/power/restart", args[0]), nil)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Server restarting...")
+			output.Infoln("Server restarting...")
 			return nil
 		},
 	})
@@ -306,13 +472,13 @@ func newServerRenameCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post("/v1/cloud/servers/"+args[0]+"/rename", map[string]string{
+			_, err := client.Post(api.Endpoint("/v1/cloud/servers/%s/rename", args[0]), map[string]string{
 				"name": name,
 			})
 			if err != nil {
 				return err
 			}
-			fmt.Printf("Server renamed to %s\n", name)
+			output.Infof("Server renamed to %s\n", name)
 			return nil
 		},
 	}
@@ -330,7 +496,7 @@ func newServerVNCCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/servers/" + args[0] + "/access/vnc")
+			resp, err := client.Get(api.Endpoint("/v1/cloud/servers/%s/access/vnc", args[0]))
 			if err != nil {
 				return err
 			}
@@ -355,7 +521,7 @@ func newServerLogsCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/servers/" + args[0] + "/logs")
+			resp, err := client.Get(api.Endpoint("/v1/cloud/servers/%s/logs", args[0]))
 			if err != nil {
 				return err
 			}
@@ -374,33 +540,122 @@ func newServerLogsCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-20s %-15s %-25s\n", "ACTION", "STATUS", "DATE")
-			fmt.Println(strings.Repeat("-", 60))
+			table := output.NewTable("ACTION", "STATUS", "DATE")
 			for _, log := range logs {
-				fmt.Printf("%-20s %-15s %-25s\n", log.Action, log.Status, log.CreatedAt)
+				table.AddRow(log.Action, log.Status, log.CreatedAt)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 }
 
+// ServerReport is one time bucket of server performance metrics
+type ServerReport struct {
+	Time       string  `json:"time"`
+	CPU        float64 `json:"cpu"`
+	RAM        float64 `json:"ram"`
+	DiskRead   float64 `json:"disk_read"`
+	DiskWrite  float64 `json:"disk_write"`
+	NetworkIn  float64 `json:"network_in"`
+	NetworkOut float64 `json:"network_out"`
+}
+
+// reportStat holds the average and peak of one metric across report buckets
+type reportStat struct {
+	Avg, Peak float64
+}
+
+func summarizeReports(reports []ServerReport, metric func(ServerReport) float64) reportStat {
+	var stat reportStat
+	if len(reports) == 0 {
+		return stat
+	}
+	var sum float64
+	for i, r := range reports {
+		v := metric(r)
+		sum += v
+		if i == 0 || v > stat.Peak {
+			stat.Peak = v
+		}
+	}
+	stat.Avg = sum / float64(len(reports))
+	return stat
+}
+
 func newServerReportsCmd() *cobra.Command {
-	return &cobra.Command{
+	var period string
+	var jsonOutput, si bool
+
+	cmd := &cobra.Command{
 		Use:   "reports [server-id]",
 		Short: "Get server performance reports",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch period {
+			case "hour", "day", "week":
+			default:
+				return fmt.Errorf("invalid --period %q: must be hour, day or week", period)
+			}
+
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/servers/" + args[0] + "/reports")
+			endpoint := api.Endpoint("/v1/cloud/servers/%s/reports", args[0]) + "?period=" + url.QueryEscape(period)
+			resp, err := client.Get(endpoint)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(string(resp.Data))
+			if jsonOutput {
+				return output.PrintJSON(resp.Data, true)
+			}
+
+			var reports []ServerReport
+			if err := json.Unmarshal(resp.Data, &reports); err != nil {
+				return fmt.Errorf("failed to parse reports: %w", err)
+			}
+			if len(reports) == 0 {
+				fmt.Println("No report data for this period")
+				return nil
+			}
+
+			units := util.IEC
+			if si {
+				units = util.SI
+			}
+			percent := func(v float64) string { return fmt.Sprintf("%.1f%%", v) }
+			rate := func(v float64) string { return util.FormatBytes(int64(v), units) + "/s" }
+
+			rows := []struct {
+				name   string
+				metric func(ServerReport) float64
+				format func(float64) string
+			}{
+				{"CPU", func(r ServerReport) float64 { return r.CPU }, percent},
+				{"RAM", func(r ServerReport) float64 { return r.RAM }, percent},
+				{"Disk Read", func(r ServerReport) float64 { return r.DiskRead }, rate},
+				{"Disk Write", func(r ServerReport) float64 { return r.DiskWrite }, rate},
+				{"Network In", func(r ServerReport) float64 { return r.NetworkIn }, rate},
+				{"Network Out", func(r ServerReport) float64 { return r.NetworkOut }, rate},
+			}
+
+			fmt.Printf("Server Reports (%s, %d samples)\n\n", period, len(reports))
+			table := output.NewTable("METRIC", "AVERAGE", "PEAK")
+			for _, row := range rows {
+				stat := summarizeReports(reports, row.metric)
+				table.AddRow(row.name, row.format(stat.Avg), row.format(stat.Peak))
+			}
+			table.Render()
+
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&period, "period", "day", "Time period (hour/day/week)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw report data as JSON")
+	cmd.Flags().BoolVar(&si, "si", false, "Use decimal SI units (1 KB = 1000 B) instead of binary units")
+
+	return cmd
 }
 
 func newServerRebuildCmd() *cobra.Command {
@@ -412,24 +667,112 @@ func newServerRebuildCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put("/v1/cloud/servers/"+args[0]+"/rebuild/software", map[string]string{
+			_, err := client.Put(api.Endpoint("/v1/cloud/servers/%s/rebuild/software", args[0]), map[string]string{
 				"os": os,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Server rebuild initiated...")
+			output.Infoln("Server rebuild initiated...")
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&os, "os", "", "New operating system")
+	cmd.Flags().StringVar(&os, "os", "", "New operating system (see 'mizban server os-list')")
+	cmd.RegisterFlagCompletionFunc("os", completeOperatingSystems)
 	cmd.MarkFlagRequired("os")
 
 	return cmd
 }
 
+func newServerResizeCmd() *cobra.Command {
+	var cpu, ram, storage int
+	var force, wait bool
+	var waitTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "resize [server-id]",
+		Short: "Change the CPU, RAM or storage of a server",
+		Long:  "Resize a server. Only the dimensions you pass are changed. Resizing usually restarts the server, so you are asked to confirm unless --force is given.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			for _, name := range []string{"cpu", "ram", "storage"} {
+				if v, _ := flags.GetInt(name); flags.Changed(name) && v <= 0 {
+					return fmt.Errorf("invalid --%s %d: must be a positive number", name, v)
+				}
+			}
+
+			client := api.NewClient()
+			before, err := getServer(client, args[0])
+			if err != nil {
+				return err
+			}
+			if flags.Changed("storage") && storage < before.Storage {
+				return fmt.Errorf("invalid --storage %d: storage can't be reduced below the current %d GB", storage, before.Storage)
+			}
+
+			after := *before
+			body := map[string]interface{}{}
+			if flags.Changed("cpu") {
+				after.CPU = cpu
+				body["cpu"] = cpu
+			}
+			if flags.Changed("ram") {
+				after.RAM = ram
+				body["ram"] = ram
+			}
+			if flags.Changed("storage") {
+				after.Storage = storage
+				body["storage"] = storage
+			}
+
+			output.Infof("Resizing server %s (%s):\n", args[0], before.Name)
+			output.Infof("  CPU:     %d -> %d cores\n", before.CPU, after.CPU)
+			output.Infof("  RAM:     %d -> %d MB\n", before.RAM, after.RAM)
+			output.Infof("  Storage: %d -> %d GB\n", before.Storage, after.Storage)
+
+			if !force && !config.GetConfig().DryRun {
+				ok, err := prompt.Confirm("The server may be restarted. Continue?")
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
+				}
+			}
+
+			_, err = client.Put(api.Endpoint("/v1/cloud/servers/%s/resize", args[0]), body)
+			if err != nil {
+				return err
+			}
+
+			output.Infoln("Server resize initiated")
+			if !wait {
+				return nil
+			}
+
+			running, err := waitForServerStatus(client, args[0], "running", waitTimeout, 5*time.Second)
+			if err != nil {
+				return err
+			}
+			output.Infof("Server is running with %d cores, %d MB RAM, %d GB storage\n", running.CPU, running.RAM, running.Storage)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&cpu, "cpu", 0, "New number of CPU cores")
+	cmd.Flags().IntVar(&ram, "ram", 0, "New RAM in MB")
+	cmd.Flags().IntVar(&storage, "storage", 0, "New storage in GB (can only grow)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the server is running again")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait with --wait (0 waits forever)")
+	cmd.MarkFlagsOneRequired("cpu", "ram", "storage")
+
+	return cmd
+}
+
 func newServerRescueCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rescue",
@@ -442,11 +785,11 @@ func newServerRescueCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post("/v1/cloud/servers/"+args[0]+"/rescue", nil)
+			_, err := client.Post(api.Endpoint("/v1/cloud/servers/%s/rescue", args[0]), nil)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Rescue mode enabled")
+			output.Infoln("Rescue mode enabled")
 			return nil
 		},
 	})
@@ -457,21 +800,169 @@ func newServerRescueCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post("/v1/cloud/servers/"+args[0]+"/unrescue", nil)
+			_, err := client.Post(api.Endpoint("/v1/cloud/servers/%s/unrescue", args[0]), nil)
+			if err != nil {
+				return err
+			}
+			output.Infoln("Rescue mode disabled")
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+func printServerDetails(w io.Writer, server *Server) {
+	fmt.Fprintf(w, "ID:         %d\n", server.ID)
+	fmt.Fprintf(w, "Name:       %s\n", server.Name)
+	fmt.Fprintf(w, "Status:     %s\n", output.ColorStatus(server.Status))
+	fmt.Fprintf(w, "CPU:        %d cores\n", server.CPU)
+	fmt.Fprintf(w, "RAM:        %d MB\n", server.RAM)
+	fmt.Fprintf(w, "Storage:    %d GB\n", server.Storage)
+	fmt.Fprintf(w, "OS:         %s\n", server.OS)
+	fmt.Fprintf(w, "Public IP:  %s\n", server.PublicIP)
+	fmt.Fprintf(w, "Private IP: %s\n", server.PrivateIP)
+	fmt.Fprintf(w, "Created:    %s\n", server.CreatedAt)
+}
+
+func newServerWaitCmd() *cobra.Command {
+	var status string
+	var waitTimeout, interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "wait [server-id]",
+		Short: "Wait until a server reaches a status",
+		Long:  "Poll a server until it reaches the requested status, exiting non-zero if the timeout elapses first.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			server, err := waitForServerStatus(client, args[0], status, waitTimeout, interval)
 			if err != nil {
 				return err
 			}
-			fmt.Println("Rescue mode disabled")
+
+			output.Infof("Server %s is %s\n", args[0], server.Status)
 			return nil
 		},
+	}
+
+	cmd.Flags().StringVar(&status, "status", "running", "Status to wait for (\"deleted\" waits until the server is gone)")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait (0 waits forever)")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between status checks")
+
+	return cmd
+}
+
+// waitForServerStatus polls a server until its status matches want
+func waitForServerStatus(client *api.Client, serverID, want string, timeout, interval time.Duration) (*Server, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid --interval %s: must be positive", interval)
+	}
+
+	start := time.Now()
+	line := output.NewStatusLine()
+	var server Server
+
+	err := util.Poll(client.Context(), timeout, interval, func() (bool, error) {
+		current, err := getServer(client, serverID)
+		if api.IsNotFound(err) {
+			server.Status = "deleted"
+			if strings.EqualFold(want, "deleted") {
+				return true, nil
+			}
+			return false, fmt.Errorf("server %s was deleted while waiting for it to be %s", serverID, want)
+		}
+		if err != nil {
+			return false, err
+		}
+		server = *current
+
+		if strings.EqualFold(server.Status, want) {
+			return true, nil
+		}
+		line.Update(fmt.Sprintf("Waiting for server %s to be %s (status: %s, %s elapsed)",
+			serverID, want, server.Status, time.Since(start).Round(time.Second)))
+		return false, nil
 	})
+	line.Done()
+
+	if errors.Is(err, util.ErrTimeout) {
+		return nil, fmt.Errorf("timed out after %s waiting for server %s to be %s (last status: %s)",
+			timeout, serverID, want, server.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+func newServerSSHCmd() *cobra.Command {
+	var user, identity string
+	var port int
+
+	cmd := &cobra.Command{
+		Use:   "ssh [server-id]",
+		Short: "Open an SSH session to a server",
+		Long:  "Look up the server's public IP and connect with the local ssh client. If ssh isn't available, the command line to run is printed instead.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			server, err := getServer(client, args[0])
+			if err != nil {
+				return err
+			}
+			if server.PublicIP == "" {
+				return fmt.Errorf("server %s has no public IP", args[0])
+			}
+
+			sshArgs := []string{}
+			if identity != "" {
+				sshArgs = append(sshArgs, "-i", identity)
+			}
+			if port != 22 {
+				sshArgs = append(sshArgs, "-p", strconv.Itoa(port))
+			}
+			sshArgs = append(sshArgs, user+"@"+server.PublicIP)
+
+			sshPath, err := exec.LookPath("ssh")
+			if err != nil {
+				fmt.Println("ssh client not found; connect with:")
+				fmt.Printf("  ssh %s\n", strings.Join(sshArgs, " "))
+				return nil
+			}
+
+			ssh := exec.Command(sshPath, sshArgs...)
+			ssh.Stdin = os.Stdin
+			ssh.Stdout = os.Stdout
+			ssh.Stderr = os.Stderr
+			if err := ssh.Run(); err != nil {
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					return fmt.Errorf("ssh exited with status %d", exitErr.ExitCode())
+				}
+				return fmt.Errorf("failed to run ssh: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&user, "user", "u", "root", "Remote user")
+	cmd.Flags().StringVarP(&identity, "identity", "i", "", "Private key file passed to ssh -i")
+	cmd.Flags().IntVarP(&port, "port", "p", 22, "SSH port")
 
 	return cmd
 }
 
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
+// getServer fetches a single server
+func getServer(client *api.Client, serverID string) (*Server, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cloud/servers/%s", serverID))
+	if err != nil {
+		return nil, err
+	}
+
+	var server Server
+	if err := json.Unmarshal(resp.Data, &server); err != nil {
+		return nil, fmt.Errorf("failed to parse server: %w", err)
 	}
-	return s[:max-3] + "..."
+	return &server, nil
 }
@@ -3,11 +3,12 @@ package cloud
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
 )
 
 type Snapshot struct {
@@ -54,9 +55,7 @@ func newSnapshotListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(snapshots, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(snapshots)
 			}
 
 			if len(snapshots) == 0 {
@@ -64,11 +63,11 @@ func newSnapshotListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-25s %-10s %-12s %-20s\n", "ID", "NAME", "SIZE(GB)", "STATUS", "CREATED")
-			fmt.Println(strings.Repeat("-", 80))
+			table := output.NewTable("ID", "NAME", "SIZE(GB)", "STATUS", "CREATED")
 			for _, s := range snapshots {
-				fmt.Printf("%-6d %-25s %-10d %-12s %-20s\n", s.ID, truncate(s.Name, 25), s.Size, s.Status, s.CreatedAt)
+				table.AddRow(s.ID, s.Name, s.Size, s.Status, s.CreatedAt)
 			}
+			table.Render()
 
 			return nil
 		},
@@ -104,9 +103,10 @@ func newSnapshotCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse snapshot: %w", err)
 			}
 
-			fmt.Printf("Snapshot created successfully!\n")
-			fmt.Printf("ID: %d\n", snapshot.ID)
-			fmt.Printf("Name: %s\n", snapshot.Name)
+			output.Infof("Snapshot created successfully!\n")
+			output.Infof("ID: %d\n", snapshot.ID)
+			output.PrintID(snapshot.ID)
+			output.Infof("Name: %s\n", snapshot.Name)
 
 			return nil
 		},
@@ -114,6 +114,7 @@ func newSnapshotCreateCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&name, "name", "", "Snapshot name")
 	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID to snapshot")
+	cmd.RegisterFlagCompletionFunc("server", completeServers)
 
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("server")
@@ -130,7 +131,7 @@ func newSnapshotGetCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/snapshots/" + args[0])
+			resp, err := client.Get(api.Endpoint("/v1/cloud/snapshots/%s", args[0]))
 			if err != nil {
 				return err
 			}
@@ -141,9 +142,7 @@ func newSnapshotGetCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(snapshot, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(snapshot)
 			}
 
 			fmt.Printf("ID:        %d\n", snapshot.ID)
@@ -171,22 +170,26 @@ func newSnapshotDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete snapshot %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete snapshot %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/snapshots/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/snapshots/%s", args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("Snapshot already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Snapshot deleted successfully")
+			output.Infoln("Snapshot deleted successfully")
 			return nil
 		},
 	}
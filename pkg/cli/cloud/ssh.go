@@ -1,13 +1,19 @@
 package cloud
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
 )
 
 type SSHKey struct {
@@ -54,9 +60,7 @@ func newSSHListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(keys, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(keys)
 			}
 
 			if len(keys) == 0 {
@@ -64,11 +68,11 @@ func newSSHListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-20s %-50s\n", "ID", "NAME", "FINGERPRINT")
-			fmt.Println(strings.Repeat("-", 80))
+			table := output.NewTable("ID", "NAME", "FINGERPRINT")
 			for _, k := range keys {
-				fmt.Printf("%-6d %-20s %-50s\n", k.ID, truncate(k.Name, 20), k.Fingerprint)
+				table.AddRow(k.ID, k.Name, k.Fingerprint)
 			}
+			table.Render()
 
 			return nil
 		},
@@ -80,17 +84,36 @@ func newSSHListCmd() *cobra.Command {
 }
 
 func newSSHAddCmd() *cobra.Command {
-	var name, publicKey string
+	var name, publicKey, keyFile string
 
 	cmd := &cobra.Command{
 		Use:   "add",
 		Short: "Add a new SSH key",
+		Long:  "Add an SSH public key. Without --key or --key-file, ~/.ssh/id_rsa.pub or ~/.ssh/id_ed25519.pub is used.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			content := publicKey
+			if content == "" {
+				path, err := resolvePublicKeyFile(keyFile)
+				if err != nil {
+					return err
+				}
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read key file: %w", err)
+				}
+				content = string(data)
+			}
+
+			key, err := validatePublicKey(content)
+			if err != nil {
+				return err
+			}
+
 			client := api.NewClient()
 
 			body := map[string]string{
 				"name":       name,
-				"public_key": publicKey,
+				"public_key": key,
 			}
 
 			resp, err := client.Post("/v1/cloud/ssh", body)
@@ -98,15 +121,16 @@ func newSSHAddCmd() *cobra.Command {
 				return err
 			}
 
-			var key SSHKey
-			if err := json.Unmarshal(resp.Data, &key); err != nil {
+			var added SSHKey
+			if err := json.Unmarshal(resp.Data, &added); err != nil {
 				return fmt.Errorf("failed to parse SSH key: %w", err)
 			}
 
-			fmt.Printf("SSH key added successfully!\n")
-			fmt.Printf("ID: %d\n", key.ID)
-			fmt.Printf("Name: %s\n", key.Name)
-			fmt.Printf("Fingerprint: %s\n", key.Fingerprint)
+			output.Infof("SSH key added successfully!\n")
+			output.Infof("ID: %d\n", added.ID)
+			output.PrintID(added.ID)
+			output.Infof("Name: %s\n", added.Name)
+			output.Infof("Fingerprint: %s\n", added.Fingerprint)
 
 			return nil
 		},
@@ -114,13 +138,88 @@ func newSSHAddCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&name, "name", "", "Key name")
 	cmd.Flags().StringVar(&publicKey, "key", "", "Public key content")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Path to public key file (default ~/.ssh/id_rsa.pub, then ~/.ssh/id_ed25519.pub)")
 
 	cmd.MarkFlagRequired("name")
-	cmd.MarkFlagRequired("key")
+	cmd.MarkFlagsMutuallyExclusive("key", "key-file")
 
 	return cmd
 }
 
+// defaultPublicKeyFiles are tried in order when no key is given
+var defaultPublicKeyFiles = []string{"id_rsa.pub", "id_ed25519.pub"}
+
+// resolvePublicKeyFile returns path, or the first default key that exists
+func resolvePublicKeyFile(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("no key given and home directory is unknown: %w", err)
+	}
+	for _, name := range defaultPublicKeyFiles {
+		candidate := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no key given and no default public key found in ~/.ssh; use --key or --key-file")
+}
+
+// sshKeyTypes are the public key algorithms accepted by validatePublicKey
+var sshKeyTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-ed25519":                        true,
+	"ssh-dss":                            true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ssh-ed25519@openssh.com":         true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+}
+
+// validatePublicKey checks that key is a single OpenSSH public key line
+// ("<type> <base64> [comment]") and returns it trimmed. Private keys are
+// rejected explicitly.
+func validatePublicKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if strings.Contains(key, "PRIVATE KEY") {
+		return "", fmt.Errorf("this is a private key; upload the public key (the .pub file) instead")
+	}
+	if key == "" {
+		return "", fmt.Errorf("public key is empty")
+	}
+	if strings.Contains(key, "\n") {
+		return "", fmt.Errorf("invalid public key: expected a single line")
+	}
+
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("invalid public key: expected \"<type> <base64-data> [comment]\"")
+	}
+	keyType := fields[0]
+	if !sshKeyTypes[keyType] {
+		return "", fmt.Errorf("invalid public key: unsupported key type %q", keyType)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: key data is not valid base64")
+	}
+	// The blob starts with the length-prefixed key type, which must match
+	if len(blob) < 4 {
+		return "", fmt.Errorf("invalid public key: key data is too short")
+	}
+	n := int(binary.BigEndian.Uint32(blob))
+	if len(blob) < 4+n || string(blob[4:4+n]) != keyType {
+		return "", fmt.Errorf("invalid public key: key data does not match type %q", keyType)
+	}
+
+	return key, nil
+}
+
 func newSSHGetCmd() *cobra.Command {
 	var jsonOutput bool
 
@@ -130,7 +229,7 @@ func newSSHGetCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/ssh/" + args[0])
+			resp, err := client.Get(api.Endpoint("/v1/cloud/ssh/%s", args[0]))
 			if err != nil {
 				return err
 			}
@@ -141,9 +240,7 @@ func newSSHGetCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(key, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(key)
 			}
 
 			fmt.Printf("ID:          %d\n", key.ID)
@@ -169,22 +266,26 @@ func newSSHDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete SSH key %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete SSH key %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/ssh/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/ssh/%s", args[0]))
+			if api.IsNotFound(err) {
+				fmt.Println("SSH key already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("SSH key deleted successfully")
+			output.Infoln("SSH key deleted successfully")
 			return nil
 		},
 	}
@@ -195,12 +296,30 @@ func newSSHDeleteCmd() *cobra.Command {
 }
 
 func newSSHGenerateCmd() *cobra.Command {
-	var name string
+	var name, savePath string
+	var printKeys, force bool
 
 	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate a new SSH key pair",
+		Long: `Generate a new SSH key pair. Without --save the private key is printed, which
+leaves it in your terminal's scrollback. With --save it is written to that path
+with mode 0600 and the public key to <path>.pub, and nothing is printed unless
+--print is also given. Existing files are never overwritten without --force.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var pubPath string
+			if savePath != "" {
+				savePath = expandHome(savePath)
+				pubPath = savePath + ".pub"
+				if !force {
+					for _, path := range []string{savePath, pubPath} {
+						if _, err := os.Stat(path); err == nil {
+							return fmt.Errorf("%s already exists (use --force to overwrite it)", path)
+						}
+					}
+				}
+			}
+
 			client := api.NewClient()
 
 			resp, err := client.Get("/v1/cloud/ssh/random")
@@ -217,19 +336,82 @@ func newSSHGenerateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse SSH key: %w", err)
 			}
 
-			fmt.Printf("SSH key pair generated successfully!\n")
-			fmt.Printf("ID: %d\n\n", result.ID)
-			fmt.Println("Private Key (save this securely):")
-			fmt.Println(result.PrivateKey)
-			fmt.Println("\nPublic Key:")
-			fmt.Println(result.PublicKey)
+			output.Infof("SSH key pair generated successfully!\n")
+			output.Infof("ID: %d\n\n", result.ID)
+			output.PrintID(result.ID)
+
+			if savePath != "" {
+				if err := writeKeyFile(savePath, result.PrivateKey, 0600, force); err != nil {
+					return err
+				}
+				if err := writeKeyFile(pubPath, result.PublicKey, 0644, force); err != nil {
+					return err
+				}
+				output.Infof("Private key saved to %s\n", savePath)
+				output.Infof("Public key saved to %s\n", pubPath)
+				if !printKeys {
+					return nil
+				}
+				output.Infoln()
+			}
+
+			output.Infoln("Private Key (save this securely):")
+			output.Infoln(result.PrivateKey)
+			output.Infoln("\nPublic Key:")
+			output.Infoln(result.PublicKey)
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Key name")
+	cmd.Flags().StringVar(&savePath, "save", "", "Write the private key to this file (mode 0600) and the public key to <file>.pub")
+	cmd.Flags().BoolVar(&printKeys, "print", false, "Also print the keys when using --save")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing key files with --save")
 	cmd.MarkFlagRequired("name")
 
 	return cmd
 }
+
+// writeKeyFile writes a key with the given permissions. Unless force is
+// set it fails if the file exists, without touching it.
+func writeKeyFile(path, key string, perm os.FileMode, force bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, perm)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// An overwritten file keeps its old mode, so set it explicitly
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if !strings.HasSuffix(key, "\n") {
+		key += "\n"
+	}
+	if _, err := f.WriteString(key); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// expandHome expands a leading ~/, which the shell leaves alone in
+// --save=~/path
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
@@ -2,12 +2,18 @@ package cloud
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/prompt"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type Volume struct {
@@ -34,6 +40,7 @@ func NewVolumeCmd() *cobra.Command {
 	cmd.AddCommand(newVolumeAttachCmd())
 	cmd.AddCommand(newVolumeDetachCmd())
 	cmd.AddCommand(newVolumeResizeCmd())
+	cmd.AddCommand(newVolumeSnapshotCmd())
 
 	return cmd
 }
@@ -57,9 +64,7 @@ func newVolumeListCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(volumes, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(volumes)
 			}
 
 			if len(volumes) == 0 {
@@ -67,15 +72,15 @@ func newVolumeListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-25s %-10s %-12s %-10s\n", "ID", "NAME", "SIZE(GB)", "STATUS", "SERVER")
-			fmt.Println(strings.Repeat("-", 70))
+			table := output.NewTable("ID", "NAME", "SIZE(GB)", "STATUS", "SERVER")
 			for _, v := range volumes {
 				serverStr := "-"
 				if v.ServerID > 0 {
 					serverStr = fmt.Sprintf("%d", v.ServerID)
 				}
-				fmt.Printf("%-6d %-25s %-10d %-12s %-10s\n", v.ID, truncate(v.Name, 25), v.Size, v.Status, serverStr)
+				table.AddRow(v.ID, v.Name, v.Size, v.Status, serverStr)
 			}
+			table.Render()
 
 			return nil
 		},
@@ -88,11 +93,13 @@ func newVolumeListCmd() *cobra.Command {
 
 func newVolumeCreateCmd() *cobra.Command {
 	var name string
-	var size, datacenter int
+	var size, datacenter, attachTo int
+	var waitTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new volume",
+		Long:  "Create a new volume. With --attach-to, wait for it to become ready and attach it to a server; the volume is kept if attaching fails.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
 
@@ -112,18 +119,36 @@ func newVolumeCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse volume: %w", err)
 			}
 
-			fmt.Printf("Volume created successfully!\n")
-			fmt.Printf("ID: %d\n", volume.ID)
-			fmt.Printf("Name: %s\n", volume.Name)
-			fmt.Printf("Size: %d GB\n", volume.Size)
+			output.Infof("Volume created successfully!\n")
+			output.Infof("ID: %d\n", volume.ID)
+			output.PrintID(volume.ID)
+			output.Infof("Name: %s\n", volume.Name)
+			output.Infof("Size: %d GB\n", volume.Size)
+
+			if attachTo == 0 {
+				return nil
+			}
+
+			volumeID := strconv.Itoa(volume.ID)
+			if _, err := waitForVolumeReady(client, volumeID, waitTimeout); err != nil {
+				return fmt.Errorf("volume %d was created but not attached: %w", volume.ID, err)
+			}
+			if err := attachVolume(client, volumeID, attachTo); err != nil {
+				return fmt.Errorf("volume %d was created but could not be attached to server %d: %w", volume.ID, attachTo, err)
+			}
 
+			output.Infof("Attached to server %d\n", attachTo)
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Volume name")
 	cmd.Flags().IntVar(&size, "size", 10, "Volume size in GB")
-	cmd.Flags().IntVar(&datacenter, "datacenter", 1, "Datacenter ID")
+	cmd.Flags().IntVar(&datacenter, "datacenter", 1, "Datacenter ID (see 'mizban datacenter list')")
+	cmd.RegisterFlagCompletionFunc("datacenter", completeDatacenters)
+	cmd.Flags().IntVar(&attachTo, "attach-to", 0, "Server ID to attach the new volume to")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait for the volume to be ready before attaching")
+	cmd.RegisterFlagCompletionFunc("attach-to", completeServers)
 
 	cmd.MarkFlagRequired("name")
 
@@ -139,7 +164,7 @@ func newVolumeGetCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			resp, err := client.Get("/v1/cloud/volumes/" + args[0])
+			resp, err := client.Get(api.Endpoint("/v1/cloud/volumes/%s", args[0]))
 			if err != nil {
 				return err
 			}
@@ -150,9 +175,7 @@ func newVolumeGetCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(volume, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(volume)
 			}
 
 			fmt.Printf("ID:        %d\n", volume.ID)
@@ -180,22 +203,26 @@ func newVolumeDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !force {
-				fmt.Printf("Are you sure you want to delete volume %s? (yes/no): ", args[0])
-				var confirm string
-				fmt.Scanln(&confirm)
-				if confirm != "yes" {
-					fmt.Println("Aborted")
-					return nil
+				ok, err := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete volume %s?", args[0]))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return prompt.ErrAborted
 				}
 			}
 
 			client := api.NewClient()
-			_, err := client.Delete("/v1/cloud/volumes/" + args[0])
+			_, err := client.Delete(api.Endpoint("/v1/cloud/volumes/%s", args[0]))
+			if api.IsNotFound(err) {
+				output.Infoln("Volume already gone")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Volume deleted successfully")
+			output.Infoln("Volume deleted successfully")
 			return nil
 		},
 	}
@@ -214,25 +241,95 @@ func newVolumeAttachCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post("/v1/cloud/volumes/attach", map[string]interface{}{
-				"volume_id": args[0],
-				"server_id": serverID,
-			})
-			if err != nil {
+			if err := attachVolume(client, args[0], serverID); err != nil {
 				return err
 			}
 
-			fmt.Println("Volume attached successfully")
+			output.Infoln("Volume attached successfully")
 			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID to attach to")
+	cmd.RegisterFlagCompletionFunc("server", completeServers)
 	cmd.MarkFlagRequired("server")
 
 	return cmd
 }
 
+func attachVolume(client *api.Client, volumeID string, serverID int) error {
+	_, err := client.Post("/v1/cloud/volumes/attach", map[string]interface{}{
+		"volume_id": volumeID,
+		"server_id": serverID,
+	})
+	return err
+}
+
+// getVolume fetches a single volume
+func getVolume(client *api.Client, volumeID string) (*Volume, error) {
+	resp, err := client.Get(api.Endpoint("/v1/cloud/volumes/%s", volumeID))
+	if err != nil {
+		return nil, err
+	}
+
+	var volume Volume
+	if err := json.Unmarshal(resp.Data, &volume); err != nil {
+		return nil, fmt.Errorf("failed to parse volume: %w", err)
+	}
+	return &volume, nil
+}
+
+// volumeReady reports whether a volume status means it can be used
+func volumeReady(status string) bool {
+	switch strings.ToLower(status) {
+	case "available", "active", "ready", "in-use", "in_use":
+		return true
+	}
+	return false
+}
+
+// waitForVolumeReady polls a volume until volumeReady accepts its status.
+// It fails early if the volume reports an error status.
+func waitForVolumeReady(client *api.Client, volumeID string, timeout time.Duration) (*Volume, error) {
+	return waitForVolume(client, volumeID, timeout, func(v *Volume) bool { return volumeReady(v.Status) })
+}
+
+// waitForVolume polls a volume until done accepts it, failing early on an
+// error status
+func waitForVolume(client *api.Client, volumeID string, timeout time.Duration, done func(*Volume) bool) (*Volume, error) {
+	start := time.Now()
+	line := output.NewStatusLine()
+	var volume Volume
+
+	err := util.Poll(client.Context(), timeout, 3*time.Second, func() (bool, error) {
+		current, err := getVolume(client, volumeID)
+		if err != nil {
+			return false, err
+		}
+		volume = *current
+
+		if done(&volume) {
+			return true, nil
+		}
+		if s := strings.ToLower(volume.Status); s == "error" || s == "failed" {
+			return false, fmt.Errorf("volume %s is in status %s", volumeID, volume.Status)
+		}
+		line.Update(fmt.Sprintf("Waiting for volume %s to be ready (status: %s, %s elapsed)",
+			volumeID, volume.Status, time.Since(start).Round(time.Second)))
+		return false, nil
+	})
+	line.Done()
+
+	if errors.Is(err, util.ErrTimeout) {
+		return nil, fmt.Errorf("timed out after %s waiting for volume %s to be ready (last status: %s)",
+			timeout, volumeID, volume.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &volume, nil
+}
+
 func newVolumeDetachCmd() *cobra.Command {
 	var serverID int
 
@@ -250,12 +347,13 @@ func newVolumeDetachCmd() *cobra.Command {
 				return err
 			}
 
-			fmt.Println("Volume detached successfully")
+			output.Infoln("Volume detached successfully")
 			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&serverID, "server", 0, "Server ID to detach from")
+	cmd.RegisterFlagCompletionFunc("server", completeServers)
 	cmd.MarkFlagRequired("server")
 
 	return cmd
@@ -263,26 +361,54 @@ func newVolumeDetachCmd() *cobra.Command {
 
 func newVolumeResizeCmd() *cobra.Command {
 	var size int
+	var wait bool
+	var waitTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "resize [volume-id]",
 		Short: "Resize volume",
+		Long:  "Grow a volume to --size GB. Volumes can't be shrunk, so the current size is checked first. The resize runs in the background; --wait polls until the volume is usable again. The filesystem on it must still be expanded from inside the server.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Put("/v1/cloud/volumes/"+args[0], map[string]interface{}{
+			volume, err := getVolume(client, args[0])
+			if err != nil {
+				return err
+			}
+			if size < volume.Size {
+				return fmt.Errorf("invalid --size %d: volume %s is %d GB and volumes can't be shrunk", size, args[0], volume.Size)
+			}
+			if size == volume.Size {
+				fmt.Printf("Volume %s is already %d GB\n", args[0], size)
+				return nil
+			}
+
+			_, err = client.Put(api.Endpoint("/v1/cloud/volumes/%s", args[0]), map[string]interface{}{
 				"size": size,
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Volume resized to %d GB\n", size)
+			fmt.Printf("Volume %s resizing from %d GB to %d GB\n", args[0], volume.Size, size)
+			if wait {
+				// The status may not have left "available" yet, so wait for the new size too
+				_, err := waitForVolume(client, args[0], waitTimeout, func(v *Volume) bool {
+					return volumeReady(v.Status) && v.Size >= size
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Volume resized to %d GB\n", size)
+			}
+			fmt.Println("Note: grow the partition and filesystem inside the server (e.g. growpart and resize2fs or xfs_growfs) to use the new space")
 			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&size, "size", 0, "New size in GB")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the resize has finished")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait with --wait (0 waits forever)")
 	cmd.MarkFlagRequired("size")
 
 	return cmd
@@ -0,0 +1,169 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
+	"github.com/mizbancloud/cli/pkg/types"
+)
+
+// VolumeSnapshot is a point-in-time copy of a single volume. Unlike server
+// snapshots it covers only that disk, not the server's OS disk.
+type VolumeSnapshot struct {
+	ID        int             `json:"id"`
+	Name      string          `json:"name"`
+	Size      int             `json:"size"`
+	Status    string          `json:"status"`
+	VolumeID  int             `json:"volume_id"`
+	CreatedAt types.Timestamp `json:"created_at"`
+}
+
+func newVolumeSnapshotCmd() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:     "snapshot [volume-id]",
+		Aliases: []string{"snapshots"},
+		Short:   "Snapshot a volume, or list and restore its snapshots",
+		Long:    "Take a point-in-time copy of a data volume, independent of any server it is attached to. Use the list and restore subcommands to find snapshots and turn one into a new volume.",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Post(api.Endpoint("/v1/cloud/volumes/%s/snapshots", args[0]), map[string]interface{}{
+				"name": name,
+			})
+			if err != nil {
+				return err
+			}
+
+			var snapshot VolumeSnapshot
+			if err := json.Unmarshal(resp.Data, &snapshot); err != nil {
+				return fmt.Errorf("failed to parse snapshot: %w", err)
+			}
+
+			output.Infof("Volume snapshot created successfully!\n")
+			output.Infof("ID: %d\n", snapshot.ID)
+			output.PrintID(snapshot.ID)
+			output.Infof("Name: %s\n", snapshot.Name)
+			output.Infof("Size: %d GB\n", snapshot.Size)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Snapshot name")
+	cmd.MarkFlagRequired("name")
+
+	cmd.AddCommand(newVolumeSnapshotListCmd())
+	cmd.AddCommand(newVolumeSnapshotRestoreCmd())
+
+	return cmd
+}
+
+func newVolumeSnapshotListCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list [volume-id]",
+		Short: "List a volume's snapshots",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := api.NewClient()
+			resp, err := client.Get(api.Endpoint("/v1/cloud/volumes/%s/snapshots", args[0]))
+			if err != nil {
+				return err
+			}
+
+			var snapshots []VolumeSnapshot
+			if err := json.Unmarshal(resp.Data, &snapshots); err != nil {
+				return fmt.Errorf("failed to parse snapshots: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSONValue(snapshots)
+			}
+
+			if len(snapshots) == 0 {
+				fmt.Println("No snapshots found")
+				return nil
+			}
+
+			table := output.NewTable("ID", "NAME", "SIZE(GB)", "STATUS", "CREATED")
+			for _, s := range snapshots {
+				table.AddRow(s.ID, s.Name, s.Size, s.Status, s.CreatedAt)
+			}
+			table.Render()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newVolumeSnapshotRestoreCmd() *cobra.Command {
+	var name string
+	var size int
+	var wait bool
+	var waitTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "restore [snapshot-id]",
+		Short: "Create a new volume from a volume snapshot",
+		Long:  "Create a new volume holding the snapshot's contents. The original volume is left untouched; attach the new one with 'mizban volume attach'.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := map[string]interface{}{
+				"name": name,
+			}
+			if cmd.Flags().Changed("size") {
+				if size < 1 {
+					return fmt.Errorf("invalid --size %d: must be a positive number", size)
+				}
+				body["size"] = size
+			}
+
+			client := api.NewClient()
+			resp, err := client.Post(api.Endpoint("/v1/cloud/volumes/snapshots/%s/restore", args[0]), body)
+			if err != nil {
+				return err
+			}
+
+			var volume Volume
+			if err := json.Unmarshal(resp.Data, &volume); err != nil {
+				return fmt.Errorf("failed to parse volume: %w", err)
+			}
+
+			output.Infof("Volume restored from snapshot %s\n", args[0])
+			output.Infof("ID: %d\n", volume.ID)
+			output.PrintID(volume.ID)
+			output.Infof("Name: %s\n", volume.Name)
+			output.Infof("Size: %d GB\n", volume.Size)
+
+			if !wait {
+				return nil
+			}
+			if _, err := waitForVolumeReady(client, strconv.Itoa(volume.ID), waitTimeout); err != nil {
+				return err
+			}
+			output.Infof("Volume %d is ready\n", volume.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name of the new volume")
+	cmd.Flags().IntVar(&size, "size", 0, "Size of the new volume in GB (default: the snapshot's size)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the new volume is ready")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait with --wait (0 waits forever)")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
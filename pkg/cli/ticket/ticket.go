@@ -1,14 +1,24 @@
 package ticket
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mizbancloud/cli/pkg/api"
+	"github.com/mizbancloud/cli/pkg/output"
 	"github.com/mizbancloud/cli/pkg/types"
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 type Ticket struct {
@@ -20,8 +30,8 @@ type Ticket struct {
 	DepartmentID int               `json:"department_id"`
 	UserID       int               `json:"user_id"`
 	IsClosed     types.NumericBool `json:"is_closed"`
-	CreatedAt    string            `json:"created_at"`
-	UpdatedAt    string            `json:"updated_at"`
+	CreatedAt    types.Timestamp   `json:"created_at"`
+	UpdatedAt    types.Timestamp   `json:"updated_at"`
 }
 
 type TicketReply struct {
@@ -33,6 +43,58 @@ type TicketReply struct {
 	UserID    int               `json:"user_id"`
 	IsStaff   types.NumericBool `json:"is_staff"`
 	CreatedAt string            `json:"created_at"`
+
+	Attachments []TicketAttachment `json:"attachments,omitempty"`
+}
+
+type TicketAttachment struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// maxAttachmentSize is the largest file accepted by --attach
+const maxAttachmentSize = 10 << 20
+
+// attachmentUpload is an --attach file as sent to the API
+type attachmentUpload struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Content  string `json:"content"`
+}
+
+// readAttachments base64-encodes the files given with --attach
+func readAttachments(paths []string) ([]attachmentUpload, error) {
+	var uploads []attachmentUpload
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("attachment %s is a directory", path)
+		}
+		if info.Size() > maxAttachmentSize {
+			return nil, fmt.Errorf("attachment %s is too large (%s; limit is %s)", path,
+				util.FormatBytes(info.Size(), util.IEC), util.FormatBytes(maxAttachmentSize, util.IEC))
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", err)
+		}
+
+		mimeType := mime.TypeByExtension(filepath.Ext(path))
+		if mimeType == "" {
+			mimeType = http.DetectContentType(data)
+		}
+		uploads = append(uploads, attachmentUpload{
+			Name:     filepath.Base(path),
+			MimeType: mimeType,
+			Content:  base64.StdEncoding.EncodeToString(data),
+		})
+	}
+	return uploads, nil
 }
 
 func NewTicketCmd() *cobra.Command {
@@ -54,18 +116,34 @@ func NewTicketCmd() *cobra.Command {
 }
 
 func newTicketListCmd() *cobra.Command {
-	var status string
+	var status, priority, department, sortBy string
 	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List tickets",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch sortBy {
+			case "", "created", "updated":
+			default:
+				return fmt.Errorf("invalid --sort %q: must be created or updated", sortBy)
+			}
+
 			client := api.NewClient()
 
-			endpoint := "/v1/support/tickets"
+			query := url.Values{}
 			if status != "" {
-				endpoint += "?status=" + status
+				query.Set("status", status)
+			}
+			if priority != "" {
+				query.Set("priority", priority)
+			}
+			if department != "" {
+				query.Set("department", department)
+			}
+			endpoint := "/v1/support/tickets"
+			if len(query) > 0 {
+				endpoint += "?" + query.Encode()
 			}
 
 			resp, err := client.Get(endpoint)
@@ -78,10 +156,14 @@ func newTicketListCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse tickets: %w", err)
 			}
 
+			// The API may ignore these parameters, so filter locally as well
+			tickets = filterTickets(tickets, priority, department)
+			if sortBy != "" {
+				sortTickets(tickets, sortBy)
+			}
+
 			if jsonOutput {
-				output, _ := json.MarshalIndent(tickets, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(tickets)
 			}
 
 			if len(tickets) == 0 {
@@ -89,38 +171,80 @@ func newTicketListCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-6s %-35s %-12s %-10s %-15s\n", "ID", "SUBJECT", "STATUS", "PRIORITY", "DEPARTMENT")
-			fmt.Println(strings.Repeat("-", 85))
+			table := output.NewTable("ID", "SUBJECT", "STATUS", "PRIORITY", "DEPARTMENT")
 			for _, t := range tickets {
-				fmt.Printf("%-6d %-35s %-12s %-10s %-15s\n",
-					t.ID, truncate(t.Subject, 35), t.Status, t.Priority, t.Department)
+				table.AddRow(t.ID, t.Subject, t.Status, t.Priority, t.Department)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status (open/closed/pending)")
+	cmd.Flags().StringVar(&priority, "priority", "", "Filter by priority (low/normal/high/urgent)")
+	cmd.Flags().StringVar(&department, "department", "", "Filter by department (support/billing/technical)")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort newest first by created or updated time")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	return cmd
 }
 
+func filterTickets(tickets []Ticket, priority, department string) []Ticket {
+	if priority == "" && department == "" {
+		return tickets
+	}
+	var filtered []Ticket
+	for _, t := range tickets {
+		if priority != "" && !strings.EqualFold(t.Priority, priority) {
+			continue
+		}
+		if department != "" && !strings.EqualFold(t.Department, department) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// sortTickets orders tickets newest first by their created or updated time.
+// Tickets with unparseable timestamps go last.
+func sortTickets(tickets []Ticket, by string) {
+	timestamp := func(t Ticket) time.Time {
+		if by == "updated" {
+			return t.UpdatedAt.Time()
+		}
+		return t.CreatedAt.Time()
+	}
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return timestamp(tickets[i]).After(timestamp(tickets[j]))
+	})
+}
+
 func newTicketCreateCmd() *cobra.Command {
 	var subject, message, department, priority string
+	var attach []string
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new ticket",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			attachments, err := readAttachments(attach)
+			if err != nil {
+				return err
+			}
+
 			client := api.NewClient()
 
-			body := map[string]string{
+			body := map[string]interface{}{
 				"subject":    subject,
 				"message":    message,
 				"department": department,
 				"priority":   priority,
 			}
+			if len(attachments) > 0 {
+				body["attachments"] = attachments
+			}
 
 			resp, err := client.Post("/v1/support/tickets", body)
 			if err != nil {
@@ -132,10 +256,11 @@ func newTicketCreateCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse ticket: %w", err)
 			}
 
-			fmt.Printf("Ticket created successfully!\n")
-			fmt.Printf("ID: %d\n", ticket.ID)
-			fmt.Printf("Subject: %s\n", ticket.Subject)
-			fmt.Printf("Status: %s\n", ticket.Status)
+			output.Infof("Ticket created successfully!\n")
+			output.Infof("ID: %d\n", ticket.ID)
+			output.PrintID(ticket.ID)
+			output.Infof("Subject: %s\n", ticket.Subject)
+			output.Infof("Status: %s\n", ticket.Status)
 
 			return nil
 		},
@@ -145,6 +270,7 @@ func newTicketCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&message, "message", "", "Ticket message")
 	cmd.Flags().StringVar(&department, "department", "support", "Department (support/billing/technical)")
 	cmd.Flags().StringVar(&priority, "priority", "normal", "Priority (low/normal/high/urgent)")
+	cmd.Flags().StringArrayVar(&attach, "attach", nil, "Attach a file (repeatable, max 10 MiB each)")
 
 	cmd.MarkFlagRequired("subject")
 	cmd.MarkFlagRequired("message")
@@ -152,32 +278,68 @@ func newTicketCreateCmd() *cobra.Command {
 	return cmd
 }
 
+// ticketDetail is a ticket together with its replies
+type ticketDetail struct {
+	Ticket  Ticket        `json:"ticket"`
+	Replies []TicketReply `json:"replies"`
+}
+
+func getTicket(client *api.Client, ticketID string) (*ticketDetail, error) {
+	resp, err := client.Get(api.Endpoint("/v1/support/tickets/%s", ticketID))
+	if err != nil {
+		return nil, err
+	}
+
+	var result ticketDetail
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse ticket: %w", err)
+	}
+	return &result, nil
+}
+
+func printReply(r TicketReply) {
+	authorType := "Customer"
+	if r.IsStaff.Bool() {
+		authorType = "Staff"
+	}
+	msg := r.Message
+	if msg == "" {
+		msg = r.Content
+	}
+	fmt.Printf("\n[%s] %s (%s):\n%s\n",
+		r.CreatedAt, r.Author, authorType, msg)
+
+	if len(r.Attachments) > 0 {
+		names := make([]string, len(r.Attachments))
+		for i, a := range r.Attachments {
+			names[i] = a.Name
+		}
+		fmt.Printf("Attachments: %s\n", strings.Join(names, ", "))
+	}
+}
+
 func newTicketGetCmd() *cobra.Command {
-	var jsonOutput bool
+	var jsonOutput, watch bool
+	var interval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "get [ticket-id]",
 		Short: "Get ticket details with replies",
+		Long:  "Show a ticket and its replies. With --watch, keep polling and print new replies as they arrive until Ctrl-C or the ticket is closed.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch && jsonOutput {
+				return fmt.Errorf("--watch cannot be combined with --json")
+			}
+
 			client := api.NewClient()
-			resp, err := client.Get("/v1/support/tickets/" + args[0])
+			result, err := getTicket(client, args[0])
 			if err != nil {
 				return err
 			}
 
-			var result struct {
-				Ticket  Ticket        `json:"ticket"`
-				Replies []TicketReply `json:"replies"`
-			}
-			if err := json.Unmarshal(resp.Data, &result); err != nil {
-				return fmt.Errorf("failed to parse ticket: %w", err)
-			}
-
 			if jsonOutput {
-				output, _ := json.MarshalIndent(result, "", "  ")
-				fmt.Println(string(output))
-				return nil
+				return output.PrintJSONValue(result)
 			}
 
 			fmt.Printf("ID:         %d\n", result.Ticket.ID)
@@ -191,50 +353,98 @@ func newTicketGetCmd() *cobra.Command {
 			if len(result.Replies) > 0 {
 				fmt.Println("\n--- Replies ---")
 				for _, r := range result.Replies {
-					authorType := "Customer"
-					if r.IsStaff.Bool() {
-						authorType = "Staff"
-					}
-					msg := r.Message
-					if msg == "" {
-						msg = r.Content
-					}
-					fmt.Printf("\n[%s] %s (%s):\n%s\n",
-						r.CreatedAt, r.Author, authorType, msg)
+					printReply(r)
 				}
 			}
 
-			return nil
+			if !watch {
+				return nil
+			}
+			return watchTicket(client, args[0], result, interval)
 		},
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep polling and print new replies as they arrive")
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "Time between polls in --watch mode")
 
 	return cmd
 }
 
+// watchTicket polls a ticket and prints replies newer than the highest reply
+// ID already shown. Staff replies ring the terminal bell.
+func watchTicket(client *api.Client, ticketID string, initial *ticketDetail, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid --interval %s: must be positive", interval)
+	}
+
+	lastID := 0
+	for _, r := range initial.Replies {
+		if r.ID > lastID {
+			lastID = r.ID
+		}
+	}
+
+	fmt.Printf("\nWatching ticket %s for new replies (Ctrl-C to stop)...\n", ticketID)
+	return util.Poll(client.Context(), 0, interval, func() (bool, error) {
+		result, err := getTicket(client, ticketID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, r := range result.Replies {
+			if r.ID <= lastID {
+				continue
+			}
+			lastID = r.ID
+			if r.IsStaff.Bool() {
+				fmt.Print("\a\n>>> New staff reply <<<\n")
+			}
+			printReply(r)
+		}
+
+		if result.Ticket.IsClosed.Bool() {
+			fmt.Println("\nTicket has been closed")
+			return true, nil
+		}
+		return false, nil
+	})
+}
+
 func newTicketReplyCmd() *cobra.Command {
 	var message string
+	var attach []string
 
 	cmd := &cobra.Command{
 		Use:   "reply [ticket-id]",
 		Short: "Reply to a ticket",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := api.NewClient()
-			_, err := client.Post("/v1/support/tickets/"+args[0]+"/replies", map[string]string{
+			attachments, err := readAttachments(attach)
+			if err != nil {
+				return err
+			}
+
+			body := map[string]interface{}{
 				"message": message,
-			})
+			}
+			if len(attachments) > 0 {
+				body["attachments"] = attachments
+			}
+
+			client := api.NewClient()
+			_, err = client.Post(api.Endpoint("/v1/support/tickets/%s/replies", args[0]), body)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Reply sent successfully")
+			output.Infoln("Reply sent successfully")
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&message, "message", "", "Reply message")
+	cmd.Flags().StringArrayVar(&attach, "attach", nil, "Attach a file (repeatable, max 10 MiB each)")
 	cmd.MarkFlagRequired("message")
 
 	return cmd
@@ -247,14 +457,14 @@ func newTicketCloseCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := api.NewClient()
-			_, err := client.Post("/v1/support/tickets/"+args[0]+"/status", map[string]string{
+			_, err := client.Post(api.Endpoint("/v1/support/tickets/%s/status", args[0]), map[string]string{
 				"status": "closed",
 			})
 			if err != nil {
 				return err
 			}
 
-			fmt.Println("Ticket closed successfully")
+			output.Infoln("Ticket closed successfully")
 			return nil
 		},
 	}
@@ -279,20 +489,13 @@ func newTicketDepartmentsCmd() *cobra.Command {
 				return fmt.Errorf("failed to parse departments: %w", err)
 			}
 
-			fmt.Printf("%-6s %-20s\n", "ID", "NAME")
-			fmt.Println(strings.Repeat("-", 30))
+			table := output.NewTable("ID", "NAME")
 			for _, d := range departments {
-				fmt.Printf("%-6d %-20s\n", d.ID, d.Name)
+				table.AddRow(d.ID, d.Name)
 			}
+			table.Render()
 
 			return nil
 		},
 	}
 }
-
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	return s[:max-3] + "..."
-}
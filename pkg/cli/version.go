@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mizbancloud/cli/pkg/config"
+	"github.com/mizbancloud/cli/pkg/output"
+)
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+func newVersionCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version and build information",
+		Long:  "Show the CLI version, git commit, build date and Go version. Include this output when reporting bugs.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := getBuildInfo()
+
+			if jsonOutput {
+				return output.PrintJSONValue(info)
+			}
+
+			fmt.Printf("Version:    %s\n", info.Version)
+			fmt.Printf("Commit:     %s\n", info.Commit)
+			fmt.Printf("Built:      %s\n", info.BuildDate)
+			fmt.Printf("Go version: %s\n", info.GoVersion)
+			fmt.Printf("Platform:   %s\n", info.Platform)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// getBuildInfo prefers the values injected with -ldflags and falls back to
+// the VCS stamp Go embeds in binaries built with go build or go install
+func getBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   config.Version,
+		Commit:    config.Commit,
+		BuildDate: config.BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" && len(s.Value) >= 7 {
+					info.Commit = s.Value[:7]
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = s.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	return info
+}
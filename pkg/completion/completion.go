@@ -0,0 +1,70 @@
+// Package completion supports dynamic shell completion backed by API lookups.
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mizbancloud/cli/pkg/config"
+)
+
+// cacheTTL is how long fetched completions are reused between key presses
+const cacheTTL = time.Minute
+
+// fetchTimeout bounds API lookups so completion never hangs the shell
+const fetchTimeout = 3 * time.Second
+
+type cacheFile struct {
+	Fetched time.Time `json:"fetched"`
+	Entries []string  `json:"entries"`
+}
+
+// Cached returns completion entries ("value\tdescription") for kind, reusing
+// a recent result from the cache directory when there is one. Any error,
+// including a timeout, yields no entries.
+func Cached(kind string, fetch func(ctx context.Context) ([]string, error)) []string {
+	path := cachePath(kind)
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			var cached cacheFile
+			if json.Unmarshal(data, &cached) == nil && time.Since(cached.Fetched) < cacheTTL {
+				return cached.Entries
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	entries, err := fetch(ctx)
+	if err != nil {
+		return nil
+	}
+
+	if path != "" {
+		if data, err := json.Marshal(cacheFile{Fetched: time.Now(), Entries: entries}); err == nil {
+			if os.MkdirAll(filepath.Dir(path), 0700) == nil {
+				os.WriteFile(path, data, 0600)
+			}
+		}
+	}
+	return entries
+}
+
+// cachePath keys the cache by account so switching tokens or API URLs
+// never offers another account's IDs
+func cachePath(kind string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	cfg := config.GetConfig()
+	h := fnv.New64a()
+	h.Write([]byte(cfg.BaseURL + "\x00" + cfg.Token))
+	return filepath.Join(dir, "mizbancloud", "completion", fmt.Sprintf("%s-%x.json", kind, h.Sum64()))
+}
@@ -1,23 +1,76 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"gopkg.in/yaml.v3"
 )
 
-var Version = "0.1.0"
+// Build metadata, overridden at build time with -ldflags -X
+var (
+	Version   = "0.1.0"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// Environment variables that override the config file
+const (
+	EnvToken  = "MIZBAN_TOKEN"
+	EnvAPIURL = "MIZBAN_API_URL"
+	EnvConfig = "MIZBAN_CONFIG"
+)
+
+// Older names still honoured when the new ones are unset
+const (
+	legacyEnvToken  = "MIZBAN_API_TOKEN"
+	legacyEnvAPIURL = "MIZBAN_BASE_URL"
+	legacyEnvConfig = "MIZBAN_CONFIG_PATH"
+)
+
+// DefaultMaxRetries is the --max-retries default
+const DefaultMaxRetries = 3
 
 var (
 	instance *Config
-	once     sync.Once
+	mu       sync.Mutex
+
+	// pathOverride is the --config flag; it wins over MIZBAN_CONFIG
+	pathOverride string
 )
 
 type Config struct {
 	Token   string `yaml:"token"`
 	BaseURL string `yaml:"base_url"`
+
+	// CredentialStore is where the token is kept: "file" (default) or
+	// "keychain"
+	CredentialStore string `yaml:"credential_store,omitempty"`
+
+	// Insecure skips TLS certificate verification, for self-hosted
+	// gateways with self-signed certificates
+	Insecure bool `yaml:"insecure,omitempty"`
+
+	// Runtime settings from global flags; never persisted
+	DryRun        bool `yaml:"-"`
+	Debug         bool `yaml:"-"`
+	SkipTLSVerify bool `yaml:"-"`
+	// MaxRetries is how often a rate-limited (429) request is retried
+	MaxRetries int `yaml:"-"`
+
+	// Values as loaded from disk and from the environment, so that
+	// environment overrides are never written back to the config file
+	fileToken   string
+	fileBaseURL string
+	envToken    string
+	envBaseURL  string
+	flagBaseURL string
 }
 
 func defaultConfigPath() string {
@@ -25,37 +78,134 @@ func defaultConfigPath() string {
 	return filepath.Join(home, ".mizbancloud", "config.yaml")
 }
 
+// Path returns the location of the config file: the --config flag, then
+// MIZBAN_CONFIG, then ~/.mizbancloud/config.yaml
+func Path() string {
+	if pathOverride != "" {
+		return pathOverride
+	}
+	if path := lookupEnv(EnvConfig, legacyEnvConfig); path != "" {
+		return path
+	}
+	return defaultConfigPath()
+}
+
+// SetPath points the config at a different file. It must run before the
+// first GetConfig; any config already loaded is discarded.
+func SetPath(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	pathOverride = path
+	instance = nil
+}
+
 func GetConfig() *Config {
-	once.Do(func() {
+	mu.Lock()
+	defer mu.Unlock()
+	if instance == nil {
 		instance = &Config{
-			BaseURL: "https://auth.mizbancloud.com/api",
+			BaseURL:    "https://auth.mizbancloud.com/api",
+			MaxRetries: DefaultMaxRetries,
 		}
 		instance.Load()
-	})
+		instance.applyEnv()
+	}
 	return instance
 }
 
 func (c *Config) Load() error {
-	path := defaultConfigPath()
+	path := Path()
 	data, err := os.ReadFile(path)
-	if err != nil {
-		return err
+	if err == nil {
+		err = yaml.Unmarshal(data, c)
+	}
+	if c.usesKeychain() {
+		token, kerr := keychainGet(path)
+		if kerr != nil {
+			warnKeychain(kerr)
+		} else if token != "" {
+			c.Token = token
+		}
 	}
-	return yaml.Unmarshal(data, c)
+	c.fileToken = c.Token
+	c.fileBaseURL = c.BaseURL
+	return err
+}
+
+func (c *Config) usesKeychain() bool {
+	return c.CredentialStore == CredentialStoreKeychain
+}
+
+func warnKeychain(err error) {
+	fmt.Fprintf(os.Stderr, "Warning: credential_store is keychain but the keychain can't be used (%v); keeping the token in the config file\n", err)
+}
+
+// applyEnv overrides file values with MIZBAN_TOKEN and MIZBAN_API_URL
+func (c *Config) applyEnv() {
+	c.envToken = lookupEnv(EnvToken, legacyEnvToken)
+	if c.envToken != "" {
+		c.Token = c.envToken
+	}
+	c.envBaseURL = lookupEnv(EnvAPIURL, legacyEnvAPIURL)
+	if c.envBaseURL != "" {
+		c.BaseURL = c.envBaseURL
+	}
+}
+
+func lookupEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
 }
 
 func (c *Config) Save() error {
-	path := defaultConfigPath()
+	path := Path()
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
 
-	data, err := yaml.Marshal(c)
+	out := *c
+	if c.envToken != "" && c.Token == c.envToken {
+		out.Token = c.fileToken
+	}
+	if c.baseURLOverridden() {
+		out.BaseURL = c.fileBaseURL
+	}
+	token := out.Token
+	if c.usesKeychain() {
+		if err := storeKeychainToken(path, token); err != nil {
+			warnKeychain(err)
+		} else {
+			out.Token = ""
+		}
+	}
+
+	data, err := yaml.Marshal(&out)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0600)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	c.fileToken = token
+	c.fileBaseURL = out.BaseURL
+	return nil
+}
+
+func storeKeychainToken(account, token string) error {
+	if token == "" {
+		return keychainDelete(account)
+	}
+	return keychainSet(account, token)
+}
+
+// TokenFromEnv reports whether the active token came from the environment
+func (c *Config) TokenFromEnv() bool {
+	return c.envToken != "" && c.Token == c.envToken
 }
 
 func (c *Config) SetToken(token string) error {
@@ -68,6 +218,31 @@ func (c *Config) SetBaseURL(url string) error {
 	return c.Save()
 }
 
+// OverrideBaseURL points this run at another API, as the --base-url flag
+// does; unlike SetBaseURL the value is never saved
+func (c *Config) OverrideBaseURL(value string) error {
+	value = strings.TrimRight(value, "/")
+	if err := checkBaseURL(value); err != nil {
+		return fmt.Errorf("invalid --base-url %q: %w", value, err)
+	}
+	c.flagBaseURL = value
+	c.BaseURL = value
+	return nil
+}
+
+// baseURLOverridden reports whether the active base URL came from the
+// environment or --base-url rather than the config file
+func (c *Config) baseURLOverridden() bool {
+	return (c.envBaseURL != "" && c.BaseURL == c.envBaseURL) ||
+		(c.flagBaseURL != "" && c.BaseURL == c.flagBaseURL)
+}
+
+// InsecureTLS reports whether TLS verification is off, from the config
+// file or the --insecure flag
+func (c *Config) InsecureTLS() bool {
+	return c.Insecure || c.SkipTLSVerify
+}
+
 func (c *Config) IsLoggedIn() bool {
 	return c.Token != ""
 }
@@ -76,3 +251,125 @@ func (c *Config) Logout() error {
 	c.Token = ""
 	return c.Save()
 }
+
+// setting describes a config key exposed by `mizban config`
+type setting struct {
+	get     func(c *Config) string
+	set     func(c *Config, value string) error
+	fromEnv func(c *Config) bool
+	secret  bool
+}
+
+var settings = map[string]setting{
+	"token": {
+		get:     func(c *Config) string { return c.Token },
+		set:     (*Config).SetToken,
+		fromEnv: (*Config).TokenFromEnv,
+		secret:  true,
+	},
+	"base_url": {
+		get:     func(c *Config) string { return c.BaseURL },
+		set:     setBaseURLChecked,
+		fromEnv: func(c *Config) bool { return c.envBaseURL != "" && c.BaseURL == c.envBaseURL },
+	},
+	"credential_store": {
+		get: func(c *Config) string {
+			if c.CredentialStore == "" {
+				return CredentialStoreFile
+			}
+			return c.CredentialStore
+		},
+		set: setCredentialStore,
+	},
+	"insecure": {
+		get: func(c *Config) string { return strconv.FormatBool(c.Insecure) },
+		set: func(c *Config, value string) error {
+			insecure, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid insecure %q: must be true or false", value)
+			}
+			c.Insecure = insecure
+			return c.Save()
+		},
+	},
+}
+
+// setCredentialStore moves the saved token to the chosen store
+func setCredentialStore(c *Config, value string) error {
+	if value != CredentialStoreFile && value != CredentialStoreKeychain {
+		return fmt.Errorf("invalid credential_store %q: must be %s or %s", value, CredentialStoreFile, CredentialStoreKeychain)
+	}
+	leavingKeychain := c.usesKeychain() && value == CredentialStoreFile
+	c.CredentialStore = value
+	if err := c.Save(); err != nil {
+		return err
+	}
+	if leavingKeychain {
+		return keychainDelete(Path())
+	}
+	return nil
+}
+
+// setBaseURLChecked validates a user-supplied base URL before saving it
+func setBaseURLChecked(c *Config, value string) error {
+	value = strings.TrimRight(value, "/")
+	if err := checkBaseURL(value); err != nil {
+		return fmt.Errorf("invalid base_url %q: %w", value, err)
+	}
+	return c.SetBaseURL(value)
+}
+
+func checkBaseURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an http or https URL")
+	}
+	return nil
+}
+
+// Keys returns the names of all settable config keys in sorted order
+func Keys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func lookupSetting(key string) (setting, error) {
+	s, ok := settings[key]
+	if !ok {
+		return setting{}, fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(Keys(), ", "))
+	}
+	return s, nil
+}
+
+// Get returns the active value of a config key
+func (c *Config) Get(key string) (string, error) {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return "", err
+	}
+	return s.get(c), nil
+}
+
+// Set updates a config key and saves the config file
+func (c *Config) Set(key, value string) error {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return err
+	}
+	return s.set(c, value)
+}
+
+// IsSecret reports whether a key's value should be masked when displayed
+func IsSecret(key string) bool {
+	return settings[key].secret
+}
+
+// FromEnv reports whether a key's active value comes from the environment
+func (c *Config) FromEnv(key string) bool {
+	s, ok := settings[key]
+	return ok && s.fromEnv != nil && s.fromEnv(c)
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Values for the credential_store setting
+const (
+	CredentialStoreFile     = "file"
+	CredentialStoreKeychain = "keychain"
+)
+
+// keychainService names the CLI's entries in the OS keychain
+const keychainService = "mizbancloud-cli"
+
+// keychainLabel is the entry's human-readable name in keychain UIs
+const keychainLabel = "MizbanCloud CLI token"
+
+var errKeychainUnavailable = errors.New("no OS keychain available")
+
+// The keychain is reached through the platform's own command-line tools so
+// the CLI stays a single static binary: security(1) on macOS and
+// secret-tool (libsecret) on Linux. Windows uses the Credential Manager
+// API directly (see keychain_windows.go). Entries are keyed by config file
+// path, so --config profiles keep separate tokens. Secrets are always
+// passed on stdin, never on the command line where other users could see
+// them in the process list.
+
+// keychainGet returns the stored secret, or "" if there is none
+func keychainGet(account string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", account, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keychainService, "account", account)
+	case "windows":
+		return credManagerGet(account)
+	default:
+		return "", errKeychainUnavailable
+	}
+
+	out, err := runKeychainTool(cmd)
+	if isKeychainNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+// keychainSet stores the secret, replacing any existing entry
+func keychainSet(account, secret string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// security only takes the password as an argument, so the command
+		// is fed to its interactive mode on stdin instead
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(securityCommand("add-generic-password", "-U", "-s", keychainService, "-a", account, "-l", keychainLabel, "-w", secret))
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label="+keychainLabel, "service", keychainService, "account", account)
+		cmd.Stdin = strings.NewReader(secret)
+	case "windows":
+		return credManagerSet(account, secret)
+	default:
+		return errKeychainUnavailable
+	}
+
+	_, err := runKeychainTool(cmd)
+	return err
+}
+
+// securityCommand formats one line for security -i, quoting every argument
+// so spaces, quotes and backslashes in it survive
+func securityCommand(args ...string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		arg = strings.ReplaceAll(arg, `\`, `\\`)
+		arg = strings.ReplaceAll(arg, `"`, `\"`)
+		quoted[i] = `"` + arg + `"`
+	}
+	return strings.Join(quoted, " ") + "\n"
+}
+
+// keychainDelete removes the entry; a missing entry is not an error
+func keychainDelete(account string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", keychainService, "-a", account)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", keychainService, "account", account)
+	case "windows":
+		return credManagerDelete(account)
+	default:
+		return errKeychainUnavailable
+	}
+
+	_, err := runKeychainTool(cmd)
+	if isKeychainNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// keychainToolError carries the tool's stderr alongside its exit status
+type keychainToolError struct {
+	tool   string
+	code   int
+	stderr string
+}
+
+func (e *keychainToolError) Error() string {
+	if e.stderr != "" {
+		return fmt.Sprintf("%s: %s", e.tool, e.stderr)
+	}
+	return fmt.Sprintf("%s exited with status %d", e.tool, e.code)
+}
+
+func runKeychainTool(cmd *exec.Cmd) (string, error) {
+	if _, err := exec.LookPath(cmd.Args[0]); err != nil {
+		return "", errKeychainUnavailable
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", &keychainToolError{tool: cmd.Args[0], code: exitErr.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+		}
+		return "", err
+	}
+	// security -i exits 0 even when a command fails; it only reports on stderr
+	if len(cmd.Args) > 1 && cmd.Args[1] == "-i" && stderr.Len() > 0 {
+		return "", &keychainToolError{tool: cmd.Args[0], code: 1, stderr: strings.TrimSpace(stderr.String())}
+	}
+	return stdout.String(), nil
+}
+
+// isKeychainNotFound tells "no such entry" apart from a keychain that
+// can't be reached: security exits 44, secret-tool exits 1 silently
+func isKeychainNotFound(err error) bool {
+	var toolErr *keychainToolError
+	if !errors.As(err, &toolErr) {
+		return false
+	}
+	if runtime.GOOS == "darwin" {
+		return toolErr.code == 44
+	}
+	return toolErr.code == 1 && toolErr.stderr == ""
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package config
+
+// The Credential Manager only exists on Windows; keychain.go never calls
+// these elsewhere
+
+func credManagerGet(account string) (string, error) {
+	return "", errKeychainUnavailable
+}
+
+func credManagerSet(account, secret string) error {
+	return errKeychainUnavailable
+}
+
+func credManagerDelete(account string) error {
+	return errKeychainUnavailable
+}
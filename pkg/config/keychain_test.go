@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestSecurityCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"plain", []string{"delete-generic-password", "-s", "svc"}, `"delete-generic-password" "-s" "svc"` + "\n"},
+		{"spaces", []string{"-l", "MizbanCloud CLI token"}, `"-l" "MizbanCloud CLI token"` + "\n"},
+		{"quotes", []string{`a"b`}, `"a\"b"` + "\n"},
+		{"backslashes", []string{`C:\path\`}, `"C:\\path\\"` + "\n"},
+		{"empty", []string{""}, `""` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := securityCommand(tt.args...); got != tt.want {
+				t.Errorf("securityCommand(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Windows Credential Manager, called through advapi32 so no extra
+// dependency or helper tool is needed. The secret is stored as a generic
+// credential named "mizbancloud-cli:<config path>".
+
+var (
+	advapi32        = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// credential mirrors the Win32 CREDENTIALW structure
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func credTarget(account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(keychainService + ":" + account)
+}
+
+// credManagerGet returns the stored secret, or "" if there is none
+func credManagerGet(account string) (string, error) {
+	target, err := credTarget(account)
+	if err != nil {
+		return "", err
+	}
+	if err := procCredReadW.Find(); err != nil {
+		return "", errKeychainUnavailable
+	}
+
+	var cred *credential
+	ok, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ok == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read from Credential Manager: %w", callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	if cred.CredentialBlobSize == 0 {
+		return "", nil
+	}
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+// credManagerSet stores the secret, replacing any existing entry
+func credManagerSet(account, secret string) error {
+	target, err := credTarget(account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	comment, err := syscall.UTF16PtrFromString(keychainLabel)
+	if err != nil {
+		return err
+	}
+	if err := procCredWriteW.Find(); err != nil {
+		return errKeychainUnavailable
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		Comment:            comment,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	ok, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ok == 0 {
+		return fmt.Errorf("failed to write to Credential Manager: %w", callErr)
+	}
+	return nil
+}
+
+// credManagerDelete removes the entry; a missing entry is not an error
+func credManagerDelete(account string) error {
+	target, err := credTarget(account)
+	if err != nil {
+		return err
+	}
+	if err := procCredDeleteW.Find(); err != nil {
+		return errKeychainUnavailable
+	}
+
+	ok, _, callErr := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ok == 0 && !errors.Is(callErr, errorNotFound) {
+		return fmt.Errorf("failed to delete from Credential Manager: %w", callErr)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// colorEnabled is decided once per run by SetColor
+var colorEnabled bool
+
+// SetColor turns ANSI colors on unless disabled by --no-color or NO_COLOR,
+// or stdout is not a terminal (e.g. piped into a file).
+func SetColor(enabled bool) {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	colorEnabled = enabled && !noColor && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// ColorStatus colors a resource status by what it means: green for healthy
+// states, red for failures and stopped resources, yellow for transitions.
+func ColorStatus(status string) string {
+	if !colorEnabled || status == "" {
+		return status
+	}
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "running", "active", "up", "enabled", "available", "ready", "success", "completed", "done", "issued", "open":
+		return ansiGreen + status + ansiReset
+	case "stopped", "down", "failed", "error", "expired", "disabled", "deleted", "suspended", "closed":
+		return ansiRed + status + ansiReset
+	case "pending", "building", "starting", "stopping", "rebooting", "resizing", "processing", "issuing", "renewing", "in-progress", "queued":
+		return ansiYellow + status + ansiReset
+	}
+	return status
+}
+
+// isStatusHeader reports whether a table column holds statuses to color
+func isStatusHeader(header string) bool {
+	return header == "STATUS" || header == "STATE"
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Set from the global --fields and --template flags; when either is set,
+// JSON output is reduced to the selected fields or rendered by the template
+var (
+	formatFields   []string
+	formatTemplate *template.Template
+)
+
+// SetFormat configures --fields (a comma-separated list of JSON fields, with
+// dots for nested ones) or --template (a Go text/template)
+func SetFormat(fields, tmpl string) error {
+	formatFields, formatTemplate = nil, nil
+	if fields != "" && tmpl != "" {
+		return fmt.Errorf("--fields and --template can't be used together")
+	}
+
+	for _, f := range strings.Split(fields, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			formatFields = append(formatFields, f)
+		}
+	}
+
+	if tmpl != "" {
+		t, err := template.New("output").Option("missingkey=zero").Parse(tmpl)
+		if err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+		formatTemplate = t
+	}
+	return nil
+}
+
+// Formatting reports whether --fields or --template is in effect
+func Formatting() bool {
+	return len(formatFields) > 0 || formatTemplate != nil
+}
+
+// PrintJSONValue marshals v and prints it like PrintJSON
+func PrintJSONValue(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to format JSON: %w", err)
+	}
+	return PrintJSON(data, true)
+}
+
+// printFormatted applies --fields or --template to a JSON payload. Lists
+// are handled item by item, one output line per item.
+func printFormatted(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+
+	var buf bytes.Buffer
+	for _, item := range items {
+		if formatTemplate != nil {
+			start := buf.Len()
+			if err := formatTemplate.Execute(&buf, item); err != nil {
+				return fmt.Errorf("failed to execute --template: %w", err)
+			}
+			if buf.Len() > start && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteByte('\n')
+			}
+			continue
+		}
+
+		values := make([]string, len(formatFields))
+		for i, field := range formatFields {
+			values[i] = fieldString(lookupField(item, field))
+		}
+		buf.WriteString(strings.Join(values, "\t"))
+		buf.WriteByte('\n')
+	}
+
+	_, err := buf.WriteTo(os.Stdout)
+	return err
+}
+
+// lookupField follows a dotted path such as "nameservers.ns1" into a
+// decoded JSON object
+func lookupField(value interface{}, path string) interface{} {
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[key]
+	}
+	return value
+}
+
+// fieldString renders a field value; objects and lists stay compact JSON
+func fieldString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
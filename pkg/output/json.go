@@ -0,0 +1,34 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// PrintJSON writes a raw JSON payload to stdout, indented when pretty is set.
+// Payloads that are not valid JSON are rejected instead of being printed as-is.
+func PrintJSON(data []byte, pretty bool) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("unexpected non-JSON response from API")
+	}
+	if Formatting() {
+		return printFormatted(data)
+	}
+
+	var buf bytes.Buffer
+	if pretty {
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+	} else {
+		if err := json.Compact(&buf, data); err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+	}
+	buf.WriteByte('\n')
+
+	_, err := buf.WriteTo(os.Stdout)
+	return err
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// progressEnabled is turned off for --json output
+var progressEnabled = true
+
+// SetProgress turns progress bars on or off for the whole run
+func SetProgress(enabled bool) {
+	progressEnabled = enabled
+}
+
+const progressBarWidth = 20
+
+// Progress shows how far a batch operation has got as a bar, a
+// current/total count and the item being processed. It redraws a single
+// line on stderr and draws nothing when stderr is not a terminal.
+type Progress struct {
+	total   int
+	current int
+	active  bool
+	width   int
+}
+
+// NewProgress starts a progress bar for total items.
+func NewProgress(total int) *Progress {
+	return &Progress{
+		total:  total,
+		active: progressEnabled && total > 1 && term.IsTerminal(int(os.Stderr.Fd())),
+	}
+}
+
+// Step advances to the next item and shows it.
+func (p *Progress) Step(item string) {
+	p.current++
+	if !p.active {
+		return
+	}
+
+	filled := p.current * progressBarWidth / p.total
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	line := fmt.Sprintf("[%s%s] %d/%d %s", strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled), p.current, p.total, item)
+	if cols, _, err := term.GetSize(int(os.Stderr.Fd())); err == nil && cols > 1 && utf8.RuneCountInString(line) >= cols {
+		line = string([]rune(line)[:cols-1])
+	}
+
+	pad := p.width - utf8.RuneCountInString(line)
+	if pad < 0 {
+		pad = 0
+	}
+	fmt.Fprint(os.Stderr, "\r"+line+strings.Repeat(" ", pad))
+	p.width = utf8.RuneCountInString(line)
+}
+
+// Clear erases the bar so other output can be printed; the next Step
+// draws it again.
+func (p *Progress) Clear() {
+	if p.active && p.width > 0 {
+		fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", p.width)+"\r")
+		p.width = 0
+	}
+}
+
+// Done erases the bar once the operation has finished.
+func (p *Progress) Done() {
+	p.Clear()
+}
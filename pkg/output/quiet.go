@@ -0,0 +1,43 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Info is where commands write informational text such as "created
+// successfully" summaries: stdout normally, stderr under --quiet. Results
+// (tables, JSON, IDs from PrintID) always go to stdout.
+var Info io.Writer = os.Stdout
+
+var quiet bool
+
+// SetQuiet moves informational text to stderr, leaving stdout for results
+func SetQuiet(q bool) {
+	quiet = q
+	if q {
+		Info = os.Stderr
+	} else {
+		Info = os.Stdout
+	}
+}
+
+// Infof writes informational text to Info
+func Infof(format string, a ...interface{}) {
+	fmt.Fprintf(Info, format, a...)
+}
+
+// Infoln writes an informational line to Info
+func Infoln(a ...interface{}) {
+	fmt.Fprintln(Info, a...)
+}
+
+// PrintID writes the ID of a newly created resource to stdout when --quiet
+// is set, so scripts can capture it. Otherwise it does nothing; commands
+// already show the ID in their summary.
+func PrintID(id interface{}) {
+	if quiet {
+		fmt.Println(id)
+	}
+}
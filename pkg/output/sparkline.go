@@ -0,0 +1,25 @@
+package output
+
+import "strings"
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a one-line bar chart scaled to the largest value
+func Sparkline(values []int64) string {
+	var max int64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if max > 0 && v > 0 {
+			i = int(v * int64(len(sparkBars)-1) / max)
+		}
+		b.WriteRune(sparkBars[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// StatusLine reports progress of a long-running wait on stderr. On a
+// terminal it redraws a single line with a spinner; otherwise it prints a
+// new line only when the message changes, so logs stay readable.
+type StatusLine struct {
+	tty   bool
+	last  string
+	width int
+	frame int
+}
+
+// NewStatusLine creates a status line writing to stderr.
+func NewStatusLine() *StatusLine {
+	return &StatusLine{tty: term.IsTerminal(int(os.Stderr.Fd()))}
+}
+
+// Update shows msg as the current status.
+func (s *StatusLine) Update(msg string) {
+	if !s.tty {
+		if msg != s.last {
+			fmt.Fprintln(os.Stderr, msg)
+		}
+		s.last = msg
+		return
+	}
+
+	line := spinnerFrames[s.frame%len(spinnerFrames)] + " " + msg
+	s.frame++
+	pad := s.width - utf8.RuneCountInString(line)
+	if pad < 0 {
+		pad = 0
+	}
+	fmt.Fprint(os.Stderr, "\r"+line+strings.Repeat(" ", pad))
+	s.width = utf8.RuneCountInString(line)
+	s.last = msg
+}
+
+// Done finishes the status line so later output starts on a fresh line.
+func (s *StatusLine) Done() {
+	if s.tty && s.width > 0 {
+		fmt.Fprintln(os.Stderr)
+		s.width = 0
+	}
+}
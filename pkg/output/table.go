@@ -0,0 +1,170 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/mizbancloud/cli/pkg/util"
+)
+
+// minColumnWidth is the narrowest a column is squeezed to on small terminals.
+const minColumnWidth = 8
+
+// columnGap separates adjacent columns.
+const columnGap = "  "
+
+// Table renders rows under a header with column widths computed from the
+// data. Numeric columns are right-aligned ("-" and empty cells don't count
+// against that). When stdout is a terminal, the widest text columns are
+// truncated so a row never wraps.
+type Table struct {
+	headers []string
+	rows    [][]string
+	indent  string
+}
+
+// NewTable creates a table with the given column headers.
+func NewTable(headers ...string) *Table {
+	return &Table{headers: headers}
+}
+
+// AddRow appends a row; each value is formatted with fmt.Sprint.
+func (t *Table) AddRow(values ...interface{}) {
+	row := make([]string, len(t.headers))
+	for i := range row {
+		if i < len(values) {
+			row[i] = fmt.Sprint(values[i])
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+// SetIndent prefixes every line of the table, for tables nested under a heading.
+func (t *Table) SetIndent(indent string) {
+	t.indent = indent
+}
+
+// Len returns the number of rows added so far.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Render writes the table to stdout.
+func (t *Table) Render() {
+	t.Fprint(os.Stdout, terminalWidth())
+}
+
+// Fprint writes the table to w, fitting it into maxWidth columns when
+// maxWidth is positive.
+func (t *Table) Fprint(w io.Writer, maxWidth int) {
+	widths := make([]int, len(t.headers))
+	numeric := make([]bool, len(t.headers))
+	text := make([]bool, len(t.headers))
+	for i, h := range t.headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+			if cell == "" || cell == "-" {
+				continue
+			}
+			if isNumber(cell) {
+				numeric[i] = true
+			} else {
+				text[i] = true
+			}
+		}
+	}
+	for i := range numeric {
+		numeric[i] = numeric[i] && !text[i]
+	}
+
+	if maxWidth > 0 {
+		shrink(widths, numeric, maxWidth-utf8.RuneCountInString(t.indent))
+	}
+
+	t.printRow(w, t.headers, widths, numeric, false)
+	fmt.Fprintln(w, t.indent+strings.Repeat("-", totalWidth(widths)))
+	for _, row := range t.rows {
+		t.printRow(w, row, widths, numeric, true)
+	}
+}
+
+func (t *Table) printRow(w io.Writer, cells []string, widths []int, numeric []bool, colored bool) {
+	var b strings.Builder
+	b.WriteString(t.indent)
+	for i, cell := range cells {
+		cell = util.Truncate(cell, widths[i])
+		pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if colored && isStatusHeader(t.headers[i]) {
+			cell = ColorStatus(cell)
+		}
+		if i > 0 {
+			b.WriteString(columnGap)
+		}
+		switch {
+		case numeric[i]:
+			b.WriteString(pad + cell)
+		case i == len(cells)-1:
+			b.WriteString(cell)
+		default:
+			b.WriteString(cell + pad)
+		}
+	}
+	fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+}
+
+// shrink narrows the widest text columns until the table fits in maxWidth.
+func shrink(widths []int, numeric []bool, maxWidth int) {
+	for totalWidth(widths) > maxWidth {
+		widest := -1
+		for i, width := range widths {
+			if numeric[i] || width <= minColumnWidth {
+				continue
+			}
+			if widest < 0 || width > widths[widest] {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+	}
+}
+
+func totalWidth(widths []int) int {
+	total := len(columnGap) * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+	return total
+}
+
+// isNumber reports whether a cell holds a plain number.
+func isNumber(cell string) bool {
+	_, err := strconv.ParseFloat(cell, 64)
+	return err == nil
+}
+
+// terminalWidth returns the width of stdout, or 0 when it isn't a terminal.
+func terminalWidth() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
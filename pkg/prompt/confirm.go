@@ -0,0 +1,50 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrAborted is returned by commands when the user declines a confirmation
+var ErrAborted = errors.New("aborted")
+
+// assumeYes is set from the global --yes flag and skips every prompt.
+var assumeYes bool
+
+// SetAssumeYes makes Confirm answer "yes" without prompting.
+func SetAssumeYes(yes bool) {
+	assumeYes = yes
+}
+
+// Confirm asks a yes/no question on stdin and reports whether the user
+// answered "yes". When stdin is not a terminal it returns an error instead of
+// silently treating the missing answer as "no".
+func Confirm(message string) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("confirmation required but stdin is not a terminal; pass --yes (or --force) to proceed")
+	}
+
+	fmt.Printf("%s (yes/no): ", message)
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "yes", "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
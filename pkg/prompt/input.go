@@ -0,0 +1,34 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IsInteractive reports whether both stdin and stdout are terminals, so a
+// command can fall back to prompting for values its flags didn't supply.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Input asks for a line of text on stdin, asking again while the answer is
+// empty. It returns ErrAborted if stdin is closed.
+func Input(message string) (string, error) {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("%s: ", message)
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer != "" {
+			return answer, nil
+		}
+		if err != nil {
+			fmt.Println()
+			return "", ErrAborted
+		}
+	}
+}
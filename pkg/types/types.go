@@ -2,7 +2,12 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mizbancloud/cli/pkg/util"
 )
 
 // NumericBool handles boolean values that come as 0/1 or true/false from API
@@ -38,11 +43,117 @@ func (b NumericBool) Bool() bool {
 	return bool(b)
 }
 
-// FlexibleString handles fields that can come as string or array of strings
+// String renders the value using the active bool style (see SetBoolStyle)
+func (b NumericBool) String() string {
+	return FormatBool(bool(b))
+}
+
+// YesNo renders the value as "Yes" or "No" regardless of the active style
+func (b NumericBool) YesNo() string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// Bool styles accepted by SetBoolStyle
+const (
+	BoolStyleTrueFalse = "true-false"
+	BoolStyleYesNo     = "yes-no"
+	BoolStyleOnOff     = "on-off"
+)
+
+var boolLabels = map[string][2]string{
+	BoolStyleTrueFalse: {"false", "true"},
+	BoolStyleYesNo:     {"No", "Yes"},
+	BoolStyleOnOff:     {"Off", "On"},
+}
+
+var boolStyle = BoolStyleYesNo
+
+// SetBoolStyle selects how FormatBool and NumericBool.String render booleans
+func SetBoolStyle(style string) error {
+	if _, ok := boolLabels[style]; !ok {
+		return fmt.Errorf("invalid bool style: %s (valid: %s, %s, %s)",
+			style, BoolStyleTrueFalse, BoolStyleYesNo, BoolStyleOnOff)
+	}
+	boolStyle = style
+	return nil
+}
+
+// FormatBool renders a plain bool using the active bool style
+func FormatBool(b bool) string {
+	labels := boolLabels[boolStyle]
+	if b {
+		return labels[1]
+	}
+	return labels[0]
+}
+
+// FlexibleInt handles integer fields that can come as a number or a numeric
+// string (e.g. 443 or "443"). null and "" decode to 0.
+type FlexibleInt int
+
+func (f *FlexibleInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = 0
+		return nil
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return fmt.Errorf("cannot use %s as an integer", data)
+		}
+		*f = FlexibleInt(v)
+	case string:
+		v = strings.TrimSpace(v)
+		if v == "" {
+			*f = 0
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			parsed, ferr := strconv.ParseFloat(v, 64)
+			if ferr != nil || parsed != float64(int(parsed)) {
+				return fmt.Errorf("cannot use %q as an integer", v)
+			}
+			n = int(parsed)
+		}
+		*f = FlexibleInt(n)
+	default:
+		return fmt.Errorf("cannot use %s as an integer", data)
+	}
+	return nil
+}
+
+func (f FlexibleInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(f))
+}
+
+func (f FlexibleInt) Int() int {
+	return int(f)
+}
+
+func (f FlexibleInt) String() string {
+	return strconv.Itoa(int(f))
+}
+
+// FlexibleString handles fields that can come as string, number or array of strings
 // It stores the first value if an array is provided
 type FlexibleString string
 
 func (f *FlexibleString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = ""
+		return nil
+	}
+
 	// Try as string first
 	var str string
 	if err := json.Unmarshal(data, &str); err == nil {
@@ -50,6 +161,13 @@ func (f *FlexibleString) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	// Numbers (e.g. IDs) keep their literal form
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err == nil {
+		*f = FlexibleString(num.String())
+		return nil
+	}
+
 	// Try as array of strings
 	var arr []string
 	if err := json.Unmarshal(data, &arr); err == nil {
@@ -74,6 +192,54 @@ func (f FlexibleString) String() string {
 	return string(f)
 }
 
+// FlexibleStringSlice handles fields that can come as a single string or an
+// array of strings. Unlike FlexibleString it keeps every value.
+type FlexibleStringSlice []string
+
+func (f *FlexibleStringSlice) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = nil
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		if str == "" {
+			*f = nil
+		} else {
+			*f = FlexibleStringSlice{str}
+		}
+		return nil
+	}
+
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err == nil {
+		var values FlexibleStringSlice
+		for _, v := range arr {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+		*f = values
+		return nil
+	}
+
+	// Default to empty
+	*f = nil
+	return nil
+}
+
+func (f FlexibleStringSlice) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(f))
+}
+
+func (f FlexibleStringSlice) String() string {
+	return strings.Join(f, ", ")
+}
+
 // NullableInt handles nullable integer fields
 type NullableInt struct {
 	Value int64
@@ -117,3 +283,87 @@ func (n NullableString) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(n.Value)
 }
+
+// NullableBool handles tri-state boolean fields where null means "not set".
+// Like NumericBool it accepts 0/1 as well as true/false.
+type NullableBool struct {
+	Value bool
+	Valid bool
+}
+
+func (n *NullableBool) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.Value = false
+		n.Valid = false
+		return nil
+	}
+	var b NumericBool
+	if err := json.Unmarshal(data, &b); err != nil {
+		return err
+	}
+	n.Value = bool(b)
+	n.Valid = true
+	return nil
+}
+
+func (n NullableBool) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// String renders the value using the active bool style, or "not set" when null
+func (n NullableBool) String() string {
+	if !n.Valid {
+		return "not set"
+	}
+	return FormatBool(n.Value)
+}
+
+// Timestamp is a time from the API. It accepts the formats listed in
+// util.ParseTime and keeps the original text for display; values that
+// can't be parsed decode without error but report IsZero.
+type Timestamp struct {
+	t   time.Time
+	raw string
+}
+
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	var raw string
+	if string(data) != "null" {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	}
+	ts.raw = strings.TrimSpace(raw)
+	ts.t = time.Time{}
+	if ts.raw != "" {
+		if t, err := util.ParseTime(ts.raw); err == nil {
+			ts.t = t
+		}
+	}
+	return nil
+}
+
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	if ts.raw == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ts.raw)
+}
+
+// Time returns the parsed time, or the zero time if missing or unparseable
+func (ts Timestamp) Time() time.Time {
+	return ts.t
+}
+
+// IsZero reports whether the timestamp is missing or couldn't be parsed
+func (ts Timestamp) IsZero() bool {
+	return ts.t.IsZero()
+}
+
+// String returns the timestamp as the API sent it
+func (ts Timestamp) String() string {
+	return ts.raw
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexibleIntUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    FlexibleInt
+		wantErr bool
+	}{
+		{"number", `5`, 5, false},
+		{"numeric string", `"5"`, 5, false},
+		{"whole float", `5.0`, 5, false},
+		{"whole float string", `"5.0"`, 5, false},
+		{"padded string", `" 5 "`, 5, false},
+		{"negative", `-3`, -3, false},
+		{"null", `null`, 0, false},
+		{"empty string", `""`, 0, false},
+		{"fractional", `5.5`, 0, true},
+		{"fractional string", `"5.5"`, 0, true},
+		{"word", `"five"`, 0, true},
+		{"bool", `true`, 0, true},
+		{"array", `[5]`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := FlexibleInt(99)
+			err := json.Unmarshal([]byte(tt.in), &f)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Unmarshal(%s) = %d, want error", tt.in, f)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) error: %v", tt.in, err)
+			}
+			if f != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, f, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlexibleIntInStruct(t *testing.T) {
+	var v struct {
+		Port FlexibleInt `json:"port"`
+	}
+	if err := json.Unmarshal([]byte(`{"port":"443"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Port != 443 {
+		t.Errorf("Port = %d, want 443", v.Port)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"port":443}` {
+		t.Errorf("Marshal = %s, want {\"port\":443}", out)
+	}
+}
@@ -0,0 +1,40 @@
+package util
+
+import "fmt"
+
+// ByteUnits selects the unit system used by FormatBytes.
+type ByteUnits int
+
+const (
+	// IEC uses binary multiples of 1024 (KiB, MiB, GiB, ...).
+	IEC ByteUnits = iota
+	// SI uses decimal multiples of 1000 (KB, MB, GB, ...).
+	SI
+)
+
+// FormatBytes renders a byte count in human readable form, e.g. "1.50 MiB"
+// with IEC units or "1.57 MB" with SI units. Negative values keep their sign.
+func FormatBytes(bytes int64, units ByteUnits) string {
+	if bytes < 0 {
+		// Work on the magnitude as uint64 so math.MinInt64 doesn't overflow.
+		return "-" + formatMagnitude(uint64(-(bytes+1))+1, units)
+	}
+	return formatMagnitude(uint64(bytes), units)
+}
+
+func formatMagnitude(bytes uint64, units ByteUnits) string {
+	unit, suffix := uint64(1024), "iB"
+	if units == SI {
+		unit, suffix = 1000, "B"
+	}
+
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := unit, 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %c%s", float64(bytes)/float64(div), "KMGTPE"[exp], suffix)
+}
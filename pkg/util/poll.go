@@ -0,0 +1,37 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTimeout is returned by Poll when the condition isn't met in time.
+var ErrTimeout = errors.New("timed out")
+
+// Poll calls check every interval until it reports done, returns an error,
+// timeout elapses or ctx is cancelled. A timeout of zero or less waits forever.
+func Poll(ctx context.Context, timeout, interval time.Duration, check func() (bool, error)) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return ErrTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
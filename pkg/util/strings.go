@@ -0,0 +1,30 @@
+package util
+
+// Truncate shortens s to at most max characters, replacing the tail with
+// "..." when it has to cut. It counts runes rather than bytes so multi-byte
+// text (e.g. Persian domain names) is never split mid-character.
+func Truncate(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= 3 {
+		return string(runes[:max])
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+// MaskSecret hides all but the last four characters of a secret
+func MaskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	if len(value) <= 8 {
+		return "********"
+	}
+	return "********" + value[len(value)-4:]
+}
@@ -0,0 +1,39 @@
+package util
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		max  int
+		want string
+	}{
+		{"empty", "", 5, ""},
+		{"shorter than max", "abc", 5, "abc"},
+		{"exactly at max", "abcde", 5, "abcde"},
+		{"ellipsis", "abcdefgh", 6, "abc..."},
+		{"max 0", "abcdef", 0, ""},
+		{"negative max", "abcdef", -1, ""},
+		{"max 1", "abcdef", 1, "a"},
+		{"max 2", "abcdef", 2, "ab"},
+		{"max 3", "abcdef", 3, "abc"},
+		{"max 4", "abcdef", 4, "a..."},
+		{"persian shorter than max", "میزبان", 10, "میزبان"},
+		{"persian exactly at max", "میزبان", 6, "میزبان"},
+		{"persian ellipsis", "میزبان‌کلود", 7, "میزب..."},
+		{"persian max 0", "میزبان", 0, ""},
+		{"persian max 1", "میزبان", 1, "م"},
+		{"persian max 2", "میزبان", 2, "می"},
+		{"persian max 3", "میزبان", 3, "میز"},
+		{"persian domain", "نمونه.ایران", 8, "نمونه..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.s, tt.max); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+			}
+		})
+	}
+}
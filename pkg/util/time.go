@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// apiTimeLayouts are the timestamp formats the API is known to return
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseTime parses a timestamp in any of the formats used by the API.
+// Values without a zone are taken as UTC.
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range apiTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format %q", s)
+}
+
+// ParseSince parses a --since value: either a duration relative to now,
+// such as "2h" or "30m", or an absolute timestamp
+func ParseSince(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", s)
+		}
+		return time.Now().Add(-d), nil
+	}
+	t, err := ParseTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a duration like 2h or a timestamp like 2006-01-02T15:04:05Z, got %q", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// countryCodes is the set of ISO 3166-1 alpha-2 country codes
+var countryCodes = map[string]bool{}
+
+func init() {
+	for _, code := range []string{
+		"AD", "AE", "AF", "AG", "AI", "AL", "AM", "AO", "AQ", "AR", "AS", "AT",
+		"AU", "AW", "AX", "AZ",
+		"BA", "BB", "BD", "BE", "BF", "BG", "BH", "BI", "BJ", "BL", "BM", "BN",
+		"BO", "BQ", "BR", "BS", "BT", "BV", "BW", "BY", "BZ",
+		"CA", "CC", "CD", "CF", "CG", "CH", "CI", "CK", "CL", "CM", "CN", "CO",
+		"CR", "CU", "CV", "CW", "CX", "CY", "CZ",
+		"DE", "DJ", "DK", "DM", "DO", "DZ",
+		"EC", "EE", "EG", "EH", "ER", "ES", "ET",
+		"FI", "FJ", "FK", "FM", "FO", "FR",
+		"GA", "GB", "GD", "GE", "GF", "GG", "GH", "GI", "GL", "GM", "GN", "GP",
+		"GQ", "GR", "GS", "GT", "GU", "GW", "GY",
+		"HK", "HM", "HN", "HR", "HT", "HU",
+		"ID", "IE", "IL", "IM", "IN", "IO", "IQ", "IR", "IS", "IT",
+		"JE", "JM", "JO", "JP",
+		"KE", "KG", "KH", "KI", "KM", "KN", "KP", "KR", "KW", "KY", "KZ",
+		"LA", "LB", "LC", "LI", "LK", "LR", "LS", "LT", "LU", "LV", "LY",
+		"MA", "MC", "MD", "ME", "MF", "MG", "MH", "MK", "ML", "MM", "MN", "MO",
+		"MP", "MQ", "MR", "MS", "MT", "MU", "MV", "MW", "MX", "MY", "MZ",
+		"NA", "NC", "NE", "NF", "NG", "NI", "NL", "NO", "NP", "NR", "NU", "NZ",
+		"OM",
+		"PA", "PE", "PF", "PG", "PH", "PK", "PL", "PM", "PN", "PR", "PS", "PT",
+		"PW", "PY",
+		"QA",
+		"RE", "RO", "RS", "RU", "RW",
+		"SA", "SB", "SC", "SD", "SE", "SG", "SH", "SI", "SJ", "SK", "SL", "SM",
+		"SN", "SO", "SR", "SS", "ST", "SV", "SX", "SY", "SZ",
+		"TC", "TD", "TF", "TG", "TH", "TJ", "TK", "TL", "TM", "TN", "TO", "TR",
+		"TT", "TV", "TW", "TZ",
+		"UA", "UG", "UM", "US", "UY", "UZ",
+		"VA", "VC", "VE", "VG", "VI", "VN", "VU",
+		"WF", "WS",
+		"YE", "YT",
+		"ZA", "ZM", "ZW",
+	} {
+		countryCodes[code] = true
+	}
+}
+
+// CountryCodes validates ISO 3166-1 alpha-2 country codes. Each value may
+// hold a comma-separated list; codes are returned uppercased and in order.
+func CountryCodes(values ...string) ([]string, error) {
+	var codes []string
+	for _, value := range values {
+		for _, code := range strings.Split(value, ",") {
+			code = strings.ToUpper(strings.TrimSpace(code))
+			if code == "" {
+				continue
+			}
+			if code == "UK" {
+				return nil, fmt.Errorf("unknown country code %q: use GB for the United Kingdom", code)
+			}
+			if !countryCodes[code] {
+				return nil, fmt.Errorf("unknown country code %q: expected a two-letter ISO 3166-1 code such as US, DE or IR", code)
+			}
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// idFlags lists the integer flags that refer to an existing resource and
+// therefore can never be zero or negative.
+var idFlags = []string{
+	"cluster",
+	"server",
+	"attach-to",
+	"record",
+	"path",
+	"forwarder",
+	"firewall",
+	"cert",
+	"cert-id",
+	"datacenter",
+	"ssh-key",
+	"plan",
+}
+
+// PositiveIDFlags rejects zero or negative values for any ID flag that was
+// set on cmd, so a bad ID fails before reaching the API.
+func PositiveIDFlags(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	for _, name := range idFlags {
+		flag := flags.Lookup(name)
+		if flag == nil || flag.Value.Type() != "int" || !flag.Changed {
+			continue
+		}
+		id, err := flags.GetInt(name)
+		if err != nil {
+			return err
+		}
+		if id <= 0 {
+			return fmt.Errorf("invalid --%s %d: must be a positive ID", name, id)
+		}
+	}
+	return nil
+}
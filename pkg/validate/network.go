@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// IP checks that s is a single IPv4 or IPv6 address
+func IP(s string) error {
+	if net.ParseIP(s) != nil {
+		return nil
+	}
+	if strings.Contains(s, "/") {
+		return fmt.Errorf("invalid IP address %q: CIDR ranges are not accepted here", s)
+	}
+	if isPartialIPv4(s) {
+		return fmt.Errorf("invalid IP address %q: expected 4 dot-separated numbers, e.g. 192.168.1.10", s)
+	}
+	return fmt.Errorf("invalid IP address %q", s)
+}
+
+// CIDR checks that s is a network in CIDR notation, such as 10.0.0.0/8
+func CIDR(s string) error {
+	addr, prefix, found := strings.Cut(s, "/")
+	if !found || prefix == "" {
+		return fmt.Errorf("invalid CIDR %q: missing prefix length", s)
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return fmt.Errorf("invalid CIDR %q: %v", s, IP(addr))
+	}
+	bits := 128
+	if ip.To4() != nil {
+		bits = 32
+	}
+	if n, err := strconv.Atoi(prefix); err != nil || n < 0 || n > bits {
+		return fmt.Errorf("invalid CIDR %q: prefix length must be between 0 and %d", s, bits)
+	}
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return fmt.Errorf("invalid CIDR %q", s)
+	}
+	return nil
+}
+
+// IPOrCIDR accepts either a single address or a CIDR range
+func IPOrCIDR(s string) error {
+	if strings.Contains(s, "/") {
+		return CIDR(s)
+	}
+	return IP(s)
+}
+
+// IPOrCIDRList validates every entry of a list flag such as --ips
+func IPOrCIDRList(values []string) error {
+	for _, v := range values {
+		if err := IPOrCIDR(strings.TrimSpace(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPartialIPv4 spots truncated addresses like "192.168.1"
+func isPartialIPv4(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) >= 4 {
+		return false
+	}
+	for _, p := range parts {
+		if _, err := strconv.Atoi(p); err != nil {
+			return false
+		}
+	}
+	return true
+}